@@ -1,17 +1,50 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
 	"github.com/yilab8/stock_auto_work/internal/revenue"
 	"github.com/yilab8/stock_auto_work/internal/server"
 )
 
+// repeatedFlag 讓 -source 可以重複指定，依命令列出現順序累積成清單。
+type repeatedFlag struct {
+	values *[]string
+}
+
+func (f *repeatedFlag) String() string {
+	if f.values == nil {
+		return ""
+	}
+	return strings.Join(*f.values, ",")
+}
+
+func (f *repeatedFlag) Set(value string) error {
+	*f.values = append(*f.values, value)
+	return nil
+}
+
 func main() {
 	addr := flag.String("addr", ":8080", "http 監聽位址")
+	financialsCacheDir := flag.String("financials-cache", "data/financials-cache", "檢表批次同步快取目錄")
+	eastmoneyCacheDir := flag.String("eastmoney-cache", "data/eastmoney-cache", "Eastmoney 風格季度檢表原始 JSON 快取目錄")
+	syncFinancials := flag.Bool("sync-financials", false, "啟動時同步最近四季的檢表資料")
+	companiesPath := flag.String("companies", "", "公司目錄檔路徑 (YAML 或 JSON)，未指定時使用內建示例資料，例如 -companies=data/companies.yaml")
+	rateLimit := flag.Float64("rate-limit", 0, "限制對 TWSE 開放資料 API 的請求速率 (次/秒)，0 表示不限制")
+	fetchCacheSpec := flag.String("fetch-cache", "", "查詢結果快取，可為 memory 或 redis:<位址>，未指定則不使用")
+	var sourceSpecs []string
+	flag.Var(&repeatedFlag{&sourceSpecs}, "source", "依序嘗試的資料來源，可重複指定，例如 -source=twse -source=mops -source=eastmoney -source=dfcf -source=csv:/path")
 	flag.Parse()
 
 	tmpl, err := template.ParseFiles("web/template/index.html")
@@ -19,10 +52,160 @@ func main() {
 		log.Fatalf("載入樣板失敗: %v", err)
 	}
 
-	app := server.NewApp(&revenue.Service{}, tmpl)
+	financialsCache := &financials.FileCache{Dir: *financialsCacheDir}
+
+	if *syncFinancials {
+		syncTrailingQuarters(&financials.BulkService{Cache: financialsCache})
+	}
+
+	var limiter *rate.Limiter
+	if *rateLimit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(*rateLimit), 1)
+	}
+
+	financialsFetchCache, revenueFetchCache, err := buildFetchCaches(*fetchCacheSpec)
+	if err != nil {
+		log.Fatalf("設定查詢快取失敗: %v", err)
+	}
+
+	revenueFetcher, err := buildRevenueFetcher(sourceSpecs, limiter, revenueFetchCache)
+	if err != nil {
+		log.Fatalf("設定營收資料來源失敗: %v", err)
+	}
+	earningsFetcher, err := buildFinancialsFetcher(sourceSpecs, financialsCache, &financials.EastmoneyRawCache{Dir: *eastmoneyCacheDir}, limiter, financialsFetchCache)
+	if err != nil {
+		log.Fatalf("設定檢表資料來源失敗: %v", err)
+	}
+
+	app := server.NewApp(revenueFetcher, earningsFetcher, tmpl)
+	if *companiesPath != "" {
+		companies, err := revenue.LoadCompanyProvider(*companiesPath)
+		if err != nil {
+			log.Fatalf("載入公司目錄失敗: %v", err)
+		}
+		app.Companies = companies
+	}
 
 	log.Printf("服務啟動於 %s", *addr)
 	if err := http.ListenAndServe(*addr, app); err != nil {
 		log.Fatalf("服務停止: %v", err)
 	}
 }
+
+// splitSourceSpec 將 "csv:/path" 這類來源字串拆成名稱與選用參數。
+func splitSourceSpec(spec string) (string, string) {
+	if idx := strings.Index(spec, ":"); idx >= 0 {
+		return spec[:idx], spec[idx+1:]
+	}
+	return spec, ""
+}
+
+// buildRevenueFetcher 依 -source 的順序組出 revenue.CompositeFetcher，並以內建示例資料作為
+// 最終後備來源；未指定 -source 時維持原本只使用 TWSE 開放資料的行為。limiter 非 nil 時套用
+// 於 TWSE 來源，對官方 API 更為友善；fetchCache 非 nil 時同樣套用於 TWSE 來源，減少重複查詢。
+func buildRevenueFetcher(specs []string, limiter *rate.Limiter, fetchCache revenue.FetchCache) (*revenue.CompositeFetcher, error) {
+	if len(specs) == 0 {
+		specs = []string{"twse"}
+	}
+	var sources []revenue.Fetcher
+	for _, spec := range specs {
+		name, arg := splitSourceSpec(spec)
+		switch name {
+		case "twse":
+			sources = append(sources, &revenue.Service{Retry: revenue.DefaultRetryPolicy(), Limiter: limiter, FetchCache: fetchCache})
+		case "csv":
+			if arg == "" {
+				return nil, fmt.Errorf("csv 來源需指定目錄，例如 -source=csv:/path")
+			}
+			sources = append(sources, &revenue.CSVFileFetcher{Dir: arg})
+		case "mops":
+			log.Printf("mops 來源僅提供季度檢表資料，營收查詢略過")
+		case "eastmoney", "dfcf":
+			log.Printf("%s 來源僅提供季度檢表資料，營收查詢略過", name)
+		default:
+			return nil, fmt.Errorf("未知的營收資料來源: %s", name)
+		}
+	}
+	sources = append(sources, revenue.StaticFetcher{})
+	return &revenue.CompositeFetcher{Sources: sources}, nil
+}
+
+// buildFinancialsFetcher 依 -source 的順序組出 financials.CompositeFetcher，並以內建
+// staticEarnings 示例資料作為最終後備來源。limiter 非 nil 時套用於 TWSE 來源，對官方 API
+// 更為友善；fetchCache 非 nil 時同樣套用於 TWSE 來源，減少重複查詢。每個 -source 都對應
+// CompositeFetcher.Sources 中扁平的一個項目，依命令列指定的順序逐一嘗試——"twse" 不會內嵌
+// 其他來源，要串接 Eastmoney 等備援必須另外明確指定 -source=eastmoney，確保設定的順序
+// (例如 -source=twse -source=mops) 會被忠實遵守。
+func buildFinancialsFetcher(specs []string, cache financials.Cache, eastmoneyCache *financials.EastmoneyRawCache, limiter *rate.Limiter, fetchCache financials.FetchCache) (*financials.CompositeFetcher, error) {
+	if len(specs) == 0 {
+		specs = []string{"twse"}
+	}
+	var sources []financials.Fetcher
+	for _, spec := range specs {
+		name, arg := splitSourceSpec(spec)
+		switch name {
+		case "twse":
+			sources = append(sources, &financials.Service{
+				Cache:      cache,
+				Retry:      financials.DefaultRetryPolicy(),
+				Limiter:    limiter,
+				FetchCache: fetchCache,
+			})
+		case "mops":
+			sources = append(sources, &financials.MOPSFetcher{})
+		case "eastmoney", "dfcf":
+			sources = append(sources, &financials.EastmoneyProvider{Cache: eastmoneyCache})
+		case "csv":
+			if arg == "" {
+				return nil, fmt.Errorf("csv 來源需指定目錄，例如 -source=csv:/path")
+			}
+			sources = append(sources, &financials.CSVFileFetcher{Dir: arg})
+		default:
+			return nil, fmt.Errorf("未知的檢表資料來源: %s", name)
+		}
+	}
+	sources = append(sources, financials.StaticFetcher{})
+	return &financials.CompositeFetcher{Sources: sources}, nil
+}
+
+// buildFetchCaches 依 -fetch-cache 設定建立 financials 與 revenue 各自的查詢快取；兩者是
+// 各套件獨立的 FetchCache 實作，即使底層共用同一個 Redis 連線。spec 為空字串時不使用快取，
+// 維持原本每次都直接呼叫遠端 API 的行為。
+func buildFetchCaches(spec string) (financials.FetchCache, revenue.FetchCache, error) {
+	name, arg := splitSourceSpec(spec)
+	switch name {
+	case "":
+		return nil, nil, nil
+	case "memory":
+		return financials.NewMemoryCache(), revenue.NewMemoryCache(), nil
+	case "redis":
+		if arg == "" {
+			return nil, nil, fmt.Errorf("redis 查詢快取需指定位址，例如 -fetch-cache=redis:localhost:6379")
+		}
+		client := redis.NewClient(&redis.Options{Addr: arg})
+		return &financials.RedisCache{Client: client}, &revenue.RedisCache{Client: client}, nil
+	default:
+		return nil, nil, fmt.Errorf("未知的查詢快取類型: %s", name)
+	}
+}
+
+// syncTrailingQuarters 同步最近四個季別的檢表資料，供離線查詢使用。
+func syncTrailingQuarters(bulk *financials.BulkService) {
+	year, quarter := currentQuarter(time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	for i := 0; i < 4; i++ {
+		if _, err := bulk.Sync(ctx, year, quarter); err != nil {
+			log.Printf("同步 %d 年第 %d 季檢表失敗: %v", year, quarter, err)
+		}
+		quarter--
+		if quarter < 1 {
+			quarter = 4
+			year--
+		}
+	}
+}
+
+func currentQuarter(now time.Time) (int, int) {
+	return now.Year(), (int(now.Month())-1)/3 + 1
+}
@@ -0,0 +1,191 @@
+package financials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultBulkEndpoint 為批次下載全體上市公司季度檢表的開放資料端點。
+const DefaultBulkEndpoint = "https://mopsov.twse.com.tw/mops/api/t187ap08_E_bulk"
+
+// SourceCache 表示資料來自批次同步後的本地快取。
+const SourceCache = "批次同步快取"
+
+// DefaultBulkPageSize 為分頁下載時每頁預設筆數。
+const DefaultBulkPageSize = 500
+
+// Cache 為批次下載結果的持久化介面，依 "year-quarter" 鍵值存取整批檢表資料。
+type Cache interface {
+	Load(key string) ([]QuarterlyReport, bool)
+	Save(key string, records []QuarterlyReport) error
+	// Keys 列出目前已快取的鍵值，供 Service 離線比對股票代號使用。
+	Keys() ([]string, error)
+}
+
+// FileCache 將批次下載結果以 JSON 檔案保存在指定目錄，每個 "year-quarter" 鍵值對應一個檔案。
+type FileCache struct {
+	Dir string
+}
+
+func (c *FileCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Load 讀取指定鍵值的快取資料。
+func (c *FileCache) Load(key string) ([]QuarterlyReport, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var records []QuarterlyReport
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, false
+	}
+	return records, true
+}
+
+// Save 將資料寫入快取目錄，檔案以原子方式覆寫避免讀到寫一半的內容。
+func (c *FileCache) Save(key string, records []QuarterlyReport) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("建立快取目錄失敗: %w", err)
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("序列化快取資料失敗: %w", err)
+	}
+	tmpPath := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("寫入快取檔案失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path(key)); err != nil {
+		return fmt.Errorf("更新快取檔案失敗: %w", err)
+	}
+	return nil
+}
+
+// Keys 列出快取目錄中所有 "year-quarter" 鍵值。
+func (c *FileCache) Keys() ([]string, error) {
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("讀取快取目錄失敗: %w", err)
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasSuffix(name, ".json") {
+			keys = append(keys, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	return keys, nil
+}
+
+// CacheKey 組出批次快取使用的鍵值。
+func CacheKey(year, quarter int) string {
+	return fmt.Sprintf("%d-%d", year, quarter)
+}
+
+// BulkService 透過分頁下載單一季別的全體上市公司檢表，並寫入 Cache 供 Service 離線查詢。
+type BulkService struct {
+	Client   *http.Client
+	Endpoint string
+	PageSize int
+	Cache    Cache
+}
+
+// Sync 逐頁下載指定年季的全體檢表資料，合併後寫入快取並回傳結果。
+func (b *BulkService) Sync(ctx context.Context, year, quarter int) ([]QuarterlyReport, error) {
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	endpoint := b.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultBulkEndpoint
+	}
+	pageSize := b.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultBulkPageSize
+	}
+
+	var all []QuarterlyReport
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("建立批次檢表請求失敗: %w", err)
+		}
+		q := req.URL.Query()
+		q.Set("year", strconv.Itoa(year))
+		q.Set("quarter", strconv.Itoa(quarter))
+		q.Set("pageNumber", strconv.Itoa(page))
+		q.Set("pageSize", strconv.Itoa(pageSize))
+		q.Set("sort", "REPORTDATE,SECURITY_CODE")
+		req.URL.RawQuery = q.Encode()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("呼叫批次檢表 API 失敗: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("讀取批次檢表回傳失敗: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("批次檢表 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
+		}
+		var rawRecords []RawQuarterRecord
+		if err := json.Unmarshal(body, &rawRecords); err != nil {
+			return nil, fmt.Errorf("解析批次檢表 JSON 失敗: %w", err)
+		}
+		if len(rawRecords) == 0 {
+			break
+		}
+		for _, rec := range rawRecords {
+			value, err := rec.Normalize()
+			if err != nil {
+				// 單筆格式錯誤不應中斷整批同步，略過即可。
+				continue
+			}
+			all = append(all, value)
+		}
+		if len(rawRecords) < pageSize {
+			break
+		}
+	}
+
+	sorted := SortQuarterlyReports(all)
+	if b.Cache != nil {
+		if err := b.Cache.Save(CacheKey(year, quarter), sorted); err != nil {
+			return sorted, err
+		}
+	}
+	return sorted, nil
+}
+
+// filterReportsByStock 篩選指定股票代號的檢表紀錄。
+func filterReportsByStock(records []QuarterlyReport, stockNo string) []QuarterlyReport {
+	key := strings.TrimSpace(stockNo)
+	var out []QuarterlyReport
+	for _, rec := range records {
+		if strings.EqualFold(strings.TrimSpace(rec.CompanyCode), key) {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package financials
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestBulkServiceSyncPagination(t *testing.T) {
+	pages := [][]RawQuarterRecord{
+		{
+			{"公司代號": "1101", "年度": "2024", "季別": "2", "稅後淨利": "1000", "基本每股盈餘": "1.0"},
+			{"公司代號": "2330", "年度": "2024", "季別": "2", "稅後淨利": "236327000", "基本每股盈餘": "9.0"},
+		},
+		{},
+	}
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("pageNumber")
+		if page != "1" && page != "2" {
+			t.Fatalf("unexpected pageNumber: %s", page)
+		}
+		calls++
+		idx := 0
+		if page == "2" {
+			idx = 1
+		}
+		if err := json.NewEncoder(w).Encode(pages[idx]); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	cache := &FileCache{Dir: t.TempDir()}
+	bulk := &BulkService{Endpoint: srv.URL, Cache: cache, PageSize: 2}
+	records, err := bulk.Sync(context.Background(), 2024, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if calls != 2 {
+		t.Fatalf("expected pagination to terminate after empty page, got %d calls", calls)
+	}
+
+	cached, ok := cache.Load(CacheKey(2024, 2))
+	if !ok || len(cached) != 2 {
+		t.Fatalf("expected sync result to be cached, got %v ok=%v", cached, ok)
+	}
+}
+
+func TestFileCacheKeys(t *testing.T) {
+	dir := t.TempDir()
+	cache := &FileCache{Dir: dir}
+	if err := cache.Save(CacheKey(2024, 1), []QuarterlyReport{{CompanyCode: "2330", Year: 2024, Quarter: 1, BasicEPS: 8.7}}); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+	keys, err := cache.Keys()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0] != CacheKey(2024, 1) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+	if _, err := cache.Keys(); err != nil {
+		t.Fatalf("unexpected error on re-read: %v", err)
+	}
+	if got := filepath.Join(dir, CacheKey(2024, 1)+".json"); got == "" {
+		t.Fatalf("unexpected empty path")
+	}
+}
+
+func TestServiceFetchUsesCacheBeforeNetwork(t *testing.T) {
+	cache := &FileCache{Dir: t.TempDir()}
+	if err := cache.Save(CacheKey(2024, 2), []QuarterlyReport{
+		{CompanyCode: "2330", Year: 2024, Quarter: 2, NetIncome: 236327000, BasicEPS: 9.0},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		json.NewEncoder(w).Encode([]RawQuarterRecord{})
+	}))
+	defer srv.Close()
+
+	svc := Service{Endpoint: srv.URL, Cache: cache}
+	result, err := svc.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Fatalf("expected cache hit to short-circuit network call")
+	}
+	if result.Source != SourceCache {
+		t.Fatalf("unexpected source: %s", result.Source)
+	}
+	if len(result.Records) != 1 {
+		t.Fatalf("unexpected record count: %d", len(result.Records))
+	}
+}
+
+func TestServiceFetchCacheSupersedesFallback(t *testing.T) {
+	cache := &FileCache{Dir: t.TempDir()}
+	if err := cache.Save(CacheKey(2024, 2), []QuarterlyReport{
+		{CompanyCode: "9999", Year: 2024, Quarter: 2, NetIncome: 1, BasicEPS: 0.1},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc := Service{Endpoint: "http://127.0.0.1:65535", Cache: cache}
+	result, err := svc.Fetch(context.Background(), "9999")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceCache {
+		t.Fatalf("expected cache to supersede fallback, got source %s", result.Source)
+	}
+}
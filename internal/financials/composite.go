@@ -0,0 +1,53 @@
+package financials
+
+import "context"
+
+// Fetcher 抽象化單一檢表資料來源，讓多個來源可以串接成 CompositeFetcher。
+type Fetcher interface {
+	Fetch(ctx context.Context, stockNo string) (FetchResult, error)
+}
+
+// CompositeFetcher 依序嘗試多個來源，回傳第一個取得非空紀錄的結果；每個來源仍依自身邏輯
+// 填入 FetchResult.Source，因此呼叫端可以得知最終資料實際來自哪個來源。
+type CompositeFetcher struct {
+	Sources []Fetcher
+}
+
+// Fetch 依序呼叫 Sources，任何來源回傳錯誤或空紀錄都會被略過並嘗試下一個。
+func (c *CompositeFetcher) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	var lastErr error
+	for _, source := range c.Sources {
+		if err := ctx.Err(); err != nil {
+			return FetchResult{}, err
+		}
+		result, err := source.Fetch(ctx, stockNo)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(result.Records) == 0 {
+			continue
+		}
+		return result, nil
+	}
+	if lastErr != nil {
+		return FetchResult{}, lastErr
+	}
+	return FetchResult{}, ErrNoData
+}
+
+// StaticFetcher 將內建示範檢表資料包裝成 Fetcher，可作為 CompositeFetcher 的最終後備來源。
+type StaticFetcher struct{}
+
+// Fetch 回傳內建示範的季度檢表資料；若查無對應股票代號則回傳 ErrNoData。
+func (StaticFetcher) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	static, ok := LookupStaticEarnings(stockNo)
+	if !ok {
+		return FetchResult{}, ErrNoData
+	}
+	return FetchResult{
+		Records: SortQuarterlyReports(cloneQuarterlyReports(static.Records)),
+		Source:  SourceFallback,
+		Note:    "改用內建檢表示例",
+	}, nil
+}
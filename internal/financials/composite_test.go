@@ -0,0 +1,90 @@
+package financials
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubFinancialsFetcher struct {
+	result FetchResult
+	err    error
+}
+
+func (s *stubFinancialsFetcher) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	return s.result, s.err
+}
+
+func TestCompositeFetcherSkipsEmptyResult(t *testing.T) {
+	want := FetchResult{
+		Records: []QuarterlyReport{{CompanyCode: "2330", Year: 2024, Quarter: 1, NetIncome: 1, BasicEPS: 1}},
+		Source:  "第二來源",
+	}
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			&stubFinancialsFetcher{result: FetchResult{Source: "第一來源"}},
+			&stubFinancialsFetcher{result: want},
+		},
+	}
+
+	result, err := composite.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != "第二來源" {
+		t.Fatalf("expected reported source to match winning fetcher, got %s", result.Source)
+	}
+	if len(result.Records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(result.Records))
+	}
+}
+
+func TestCompositeFetcherStopsOnContextCancellation(t *testing.T) {
+	called := false
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			&stubFinancialsFetcher{err: errors.New("first source down")},
+			&financialsFetcherFunc{func(ctx context.Context, stockNo string) (FetchResult, error) {
+				called = true
+				return FetchResult{}, nil
+			}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := composite.Fetch(ctx, "2330")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if called {
+		t.Fatalf("expected remaining sources to be skipped after cancellation")
+	}
+}
+
+func TestCompositeFetcherFallsBackToStatic(t *testing.T) {
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			&stubFinancialsFetcher{err: errors.New("remote unavailable")},
+			StaticFetcher{},
+		},
+	}
+
+	result, err := composite.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceFallback {
+		t.Fatalf("expected fallback source, got %s", result.Source)
+	}
+}
+
+// financialsFetcherFunc 將函式包裝成 Fetcher，方便在測試中斷言某個來源是否被呼叫到。
+type financialsFetcherFunc struct {
+	fn func(ctx context.Context, stockNo string) (FetchResult, error)
+}
+
+func (f *financialsFetcherFunc) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	return f.fn(ctx, stockNo)
+}
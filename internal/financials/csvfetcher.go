@@ -0,0 +1,118 @@
+package financials
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SourceCSV 表示資料來自使用者提供的 CSV 檔案。
+const SourceCSV = "本地 CSV 檔案"
+
+// CSVFileFetcher 掃描指定目錄下的 CSV 檔案，讀取欄位 stock_no,year,quarter,net_income,eps 並
+// 篩選出指定股票代號的季度檢表，方便開發期間以本地資料覆寫遠端來源。
+type CSVFileFetcher struct {
+	Dir string
+}
+
+// Fetch 讀取 Dir 底下所有 *.csv 檔案，彙整出指定股票代號的季度檢表資料。
+func (f *CSVFileFetcher) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	key := strings.TrimSpace(stockNo)
+	if key == "" {
+		return FetchResult{}, fmt.Errorf("stockNo 為必填")
+	}
+	entries, err := os.ReadDir(f.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return FetchResult{}, ErrNoData
+		}
+		return FetchResult{}, fmt.Errorf("讀取 CSV 目錄失敗: %w", err)
+	}
+
+	var records []QuarterlyReport
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return FetchResult{}, err
+		}
+		if entry.IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name()), ".csv") {
+			continue
+		}
+		rows, err := readCSVReports(filepath.Join(f.Dir, entry.Name()), key)
+		if err != nil {
+			return FetchResult{}, err
+		}
+		records = append(records, rows...)
+	}
+	if len(records) == 0 {
+		return FetchResult{}, ErrNoData
+	}
+	return FetchResult{
+		Records: SortQuarterlyReports(records),
+		Source:  SourceCSV,
+		Note:    fmt.Sprintf("資料來自本地 CSV 目錄 %s", f.Dir),
+	}, nil
+}
+
+// readCSVReports 解析單一 CSV 檔案，回傳指定股票代號 (stock_no,year,quarter,net_income,eps) 的紀錄。
+func readCSVReports(path, stockNo string) ([]QuarterlyReport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("開啟 CSV 檔案失敗: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("讀取 CSV 標頭失敗: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range []string{"stock_no", "year", "quarter", "net_income", "eps"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV 檔案 %s 缺少欄位 %s", path, required)
+		}
+	}
+
+	var out []QuarterlyReport
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("讀取 CSV 內容失敗: %w", err)
+		}
+		if row[columns["stock_no"]] != stockNo {
+			continue
+		}
+		year, err := strconv.Atoi(strings.TrimSpace(row[columns["year"]]))
+		if err != nil {
+			return nil, fmt.Errorf("年度欄位格式錯誤: %w", err)
+		}
+		quarter, err := strconv.Atoi(strings.TrimSpace(row[columns["quarter"]]))
+		if err != nil {
+			return nil, fmt.Errorf("季別欄位格式錯誤: %w", err)
+		}
+		netIncome, err := strconv.ParseFloat(strings.TrimSpace(row[columns["net_income"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("稅後淨利欄位格式錯誤: %w", err)
+		}
+		eps, err := strconv.ParseFloat(strings.TrimSpace(row[columns["eps"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("EPS 欄位格式錯誤: %w", err)
+		}
+		out = append(out, QuarterlyReport{CompanyCode: stockNo, Year: year, Quarter: quarter, NetIncome: netIncome, BasicEPS: eps})
+	}
+	return out, nil
+}
@@ -0,0 +1,301 @@
+package financials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEastmoneyEndpoint 為東方財富 (Eastmoney / dfcf) 的季度檢表查詢端點，使用
+// RPT_LICO_FN_CPD 資料集。
+const DefaultEastmoneyEndpoint = "https://datacenter-web.eastmoney.com/api/data/v1/get"
+
+// SourceEastmoney 表示資料來自東方財富 (Eastmoney / dfcf) 季度檢表查詢。
+const SourceEastmoney = "Eastmoney (dfcf) 季度檢表"
+
+// DefaultEastmoneyPageSize 為 EastmoneyProvider 分頁查詢時每頁預設筆數。
+const DefaultEastmoneyPageSize = 50
+
+// DefaultEastmoneyYears 為未指定 Years 時，EastmoneyProvider 預設回溯查詢的年數。
+const DefaultEastmoneyYears = 3
+
+// DefaultEastmoneyTimeout 為未指定 Timeout 時，單次 Fetch/FetchQuarterly 呼叫遠端 API 的
+// 預設逾時時間。
+const DefaultEastmoneyTimeout = 10 * time.Second
+
+// eastmoneyRecord 對應東方財富 RPT_LICO_FN_CPD 資料集回傳的單筆原始欄位，直接以英文欄位
+// 名稱解析，不走 RawQuarterRecord 的中文欄位對照表。
+type eastmoneyRecord struct {
+	SecurityCode    string   `json:"SECURITY_CODE"`
+	ReportDate      string   `json:"REPORTDATE"`
+	ParentNetProfit *float64 `json:"PARENT_NETPROFIT"`
+	BasicEPS        *float64 `json:"BASIC_EPS"`
+}
+
+// eastmoneyResponse 對應東方財富 API 的回傳信封，data 為本頁紀錄、pages 為總頁數。
+type eastmoneyResponse struct {
+	Result *struct {
+		Pages int               `json:"pages"`
+		Data  []eastmoneyRecord `json:"data"`
+	} `json:"result"`
+}
+
+// EastmoneyRawCache 依報告日期 (例如 "2024-03-31") 保存單季下載到的原始紀錄，讓
+// EastmoneyProvider 在同一季度重複被查詢 (不同股票代號) 時不必重新呼叫遠端 API。
+type EastmoneyRawCache struct {
+	Dir string
+}
+
+func (c *EastmoneyRawCache) path(reportDate string) string {
+	return filepath.Join(c.Dir, reportDate+".json")
+}
+
+// Load 讀取指定報告日期的原始檢表快取。
+func (c *EastmoneyRawCache) Load(reportDate string) ([]eastmoneyRecord, bool) {
+	data, err := os.ReadFile(c.path(reportDate))
+	if err != nil {
+		return nil, false
+	}
+	var records []eastmoneyRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, false
+	}
+	return records, true
+}
+
+// Save 將原始檢表資料以 JSON 寫入快取目錄，以原子方式覆寫避免讀到寫一半的內容。
+func (c *EastmoneyRawCache) Save(reportDate string, records []eastmoneyRecord) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("建立 Eastmoney 快取目錄失敗: %w", err)
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("序列化 Eastmoney 快取資料失敗: %w", err)
+	}
+	tmpPath := c.path(reportDate) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("寫入 Eastmoney 快取檔案失敗: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path(reportDate)); err != nil {
+		return fmt.Errorf("更新 Eastmoney 快取檔案失敗: %w", err)
+	}
+	return nil
+}
+
+// EastmoneyProvider 透過東方財富 (Eastmoney / dfcf) RPT_LICO_FN_CPD 資料集分頁查詢 A 股
+// 季度淨利與 EPS，可作為 TWSE 之外的備援 Provider，讓兩岸三地跨掛牌的股票也能取得季度檢
+// 表，並在 TWSE 無法連線時提供一條備援查詢路徑。除了實作 Provider.FetchQuarterly 供
+// Service.Fetch 內部編排使用外，也實作 Fetcher.Fetch，可單獨掛進 CompositeFetcher。
+type EastmoneyProvider struct {
+	Client   *http.Client
+	Endpoint string
+	PageSize int
+	// Timeout 為單次查詢呼叫遠端 API 的逾時時間；未設定時使用 DefaultEastmoneyTimeout。
+	Timeout time.Duration
+	// QuarterEnd 指定回溯查詢的起始季度末日期；未設定時使用目前時間所在的季度。
+	QuarterEnd time.Time
+	// Years 指定回溯查詢的年數；未設定時使用 DefaultEastmoneyYears。
+	Years int
+	// Cache 為選用的單季原始紀錄快取，設定後同一季度只需下載一次即可供多個股票代號查詢。
+	Cache *EastmoneyRawCache
+}
+
+// Fetch 為 Fetcher 介面實作，直接轉呼叫 FetchQuarterly，讓 EastmoneyProvider 可單獨掛進
+// CompositeFetcher.Sources 使用。
+func (p *EastmoneyProvider) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	return p.FetchQuarterly(ctx, stockNo)
+}
+
+// FetchQuarterly 為 Provider 介面實作，依 SECURITY_CODE 分頁下載最近 Years 年的季度檢表，
+// 回傳合併排序後的結果。
+func (p *EastmoneyProvider) FetchQuarterly(ctx context.Context, stockNo string) (FetchResult, error) {
+	key := strings.TrimSpace(stockNo)
+	if key == "" {
+		return FetchResult{}, fmt.Errorf("stockNo 為必填")
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultEastmoneyTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	end := p.QuarterEnd
+	if end.IsZero() {
+		end = time.Now()
+	}
+	years := p.Years
+	if years <= 0 {
+		years = DefaultEastmoneyYears
+	}
+
+	year, quarter := quarterOf(end)
+	var all []QuarterlyReport
+	for i := 0; i < years*4; i++ {
+		if err := ctx.Err(); err != nil {
+			return FetchResult{}, err
+		}
+		records, err := p.fetchQuarter(ctx, key, year, quarter)
+		if err != nil {
+			return FetchResult{}, err
+		}
+		all = append(all, records...)
+		quarter--
+		if quarter < 1 {
+			quarter = 4
+			year--
+		}
+	}
+
+	if len(all) == 0 {
+		return FetchResult{}, ErrNoData
+	}
+	return FetchResult{
+		Records: SortQuarterlyReports(all),
+		Source:  SourceEastmoney,
+		Note:    "資料來自東方財富 (Eastmoney / dfcf) 季度檢表查詢",
+	}, nil
+}
+
+// fetchQuarter 下載 (或讀取快取) 指定年季在 RPT_LICO_FN_CPD 資料集中的紀錄，並篩選出指定
+// 股票代號。
+func (p *EastmoneyProvider) fetchQuarter(ctx context.Context, stockNo string, year, quarter int) ([]QuarterlyReport, error) {
+	reportDate := quarterEndDate(year, quarter)
+
+	var raw []eastmoneyRecord
+	if p.Cache != nil {
+		if cached, ok := p.Cache.Load(reportDate); ok {
+			raw = cached
+		}
+	}
+	if raw == nil {
+		fetched, err := p.fetchPages(ctx, reportDate)
+		if err != nil {
+			return nil, err
+		}
+		raw = fetched
+		if p.Cache != nil && len(raw) > 0 {
+			if err := p.Cache.Save(reportDate, raw); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out := make([]QuarterlyReport, 0, len(raw))
+	for _, rec := range raw {
+		if !strings.EqualFold(strings.TrimSpace(rec.SecurityCode), stockNo) {
+			continue
+		}
+		value, err := rec.normalize()
+		if err != nil {
+			// 單筆格式錯誤不應中斷整批查詢，略過即可。
+			continue
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+// fetchPages 向東方財富 REST 端點分頁下載指定報告日期的全體檢表原始紀錄 (依 REPORTDATE,
+// SECURITY_CODE 排序)，直到本頁筆數小於 pageSize 或已達回傳的 pages 頁數為止。
+func (p *EastmoneyProvider) fetchPages(ctx context.Context, reportDate string) ([]eastmoneyRecord, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEastmoneyEndpoint
+	}
+	pageSize := p.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultEastmoneyPageSize
+	}
+
+	var all []eastmoneyRecord
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("建立 Eastmoney 請求失敗: %w", err)
+		}
+		q := req.URL.Query()
+		q.Set("reportName", "RPT_LICO_FN_CPD")
+		q.Set("sortColumns", "REPORTDATE,SECURITY_CODE")
+		q.Set("sortTypes", "-1,1")
+		q.Set("filter", fmt.Sprintf("(REPORTDATE='%s')", reportDate))
+		q.Set("pageNumber", strconv.Itoa(page))
+		q.Set("pageSize", strconv.Itoa(pageSize))
+		req.URL.RawQuery = q.Encode()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("呼叫 Eastmoney 季度檢表 API 失敗: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("讀取 Eastmoney 回傳內容失敗: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Eastmoney 季度檢表 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
+		}
+		var payload eastmoneyResponse
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("解析 Eastmoney 季度檢表 JSON 失敗: %w", err)
+		}
+		if payload.Result == nil || len(payload.Result.Data) == 0 {
+			break
+		}
+		all = append(all, payload.Result.Data...)
+		if len(payload.Result.Data) < pageSize || (payload.Result.Pages > 0 && page >= payload.Result.Pages) {
+			break
+		}
+	}
+	return all, nil
+}
+
+// normalize 將 Eastmoney 原始欄位轉換為 QuarterlyReport；缺少 PARENT_NETPROFIT 或
+// REPORTDATE 格式錯誤時回傳錯誤。
+func (r eastmoneyRecord) normalize() (QuarterlyReport, error) {
+	if r.ParentNetProfit == nil {
+		return QuarterlyReport{}, fmt.Errorf("缺少 PARENT_NETPROFIT 欄位")
+	}
+	reportTime, err := time.Parse("2006-01-02", strings.SplitN(r.ReportDate, " ", 2)[0])
+	if err != nil {
+		return QuarterlyReport{}, fmt.Errorf("REPORTDATE 格式錯誤: %w", err)
+	}
+	year, quarter := quarterOf(reportTime)
+	eps := 0.0
+	if r.BasicEPS != nil {
+		eps = *r.BasicEPS
+	}
+	return QuarterlyReport{
+		CompanyCode: strings.TrimSpace(r.SecurityCode),
+		Year:        year,
+		Quarter:     quarter,
+		NetIncome:   *r.ParentNetProfit,
+		BasicEPS:    eps,
+	}, nil
+}
+
+// quarterOf 回傳指定時間所在的年度與季別。
+func quarterOf(t time.Time) (int, int) {
+	return t.Year(), (int(t.Month())-1)/3 + 1
+}
+
+// quarterEndDate 回傳年季對應的季度末日期字串 (YYYY-MM-DD)，供 filter 參數使用。
+func quarterEndDate(year, quarter int) string {
+	endDates := map[int]string{1: "03-31", 2: "06-30", 3: "09-30", 4: "12-31"}
+	return fmt.Sprintf("%d-%s", year, endDates[quarter])
+}
@@ -0,0 +1,151 @@
+package financials
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func eastmoneyPayload(pages int, records ...eastmoneyRecord) eastmoneyResponse {
+	return eastmoneyResponse{Result: &struct {
+		Pages int               `json:"pages"`
+		Data  []eastmoneyRecord `json:"data"`
+	}{Pages: pages, Data: records}}
+}
+
+func eastmoneyNumber(v float64) *float64 { return &v }
+
+func TestEastmoneyProviderFetchQuarterPaginatesAndFilters(t *testing.T) {
+	netProfit := eastmoneyNumber(225514000)
+	eps := eastmoneyNumber(8.70)
+	pages := []eastmoneyResponse{
+		eastmoneyPayload(2, eastmoneyRecord{SecurityCode: "1101", ReportDate: "2024-03-31", ParentNetProfit: eastmoneyNumber(1000), BasicEPS: eastmoneyNumber(1.0)},
+			eastmoneyRecord{SecurityCode: "2330", ReportDate: "2024-03-31", ParentNetProfit: netProfit, BasicEPS: eps}),
+		eastmoneyPayload(2),
+	}
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter"); got != "(REPORTDATE='2024-03-31')" {
+			t.Fatalf("unexpected filter: %s", got)
+		}
+		page := r.URL.Query().Get("pageNumber")
+		calls++
+		idx := 0
+		if page == "2" {
+			idx = 1
+		}
+		if err := json.NewEncoder(w).Encode(pages[idx]); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	provider := &EastmoneyProvider{Endpoint: srv.URL, PageSize: 2}
+	records, err := provider.fetchQuarter(context.Background(), "2330", 2024, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record for 2330, got %d", len(records))
+	}
+	if records[0].BasicEPS != 8.70 {
+		t.Fatalf("unexpected eps: %v", records[0].BasicEPS)
+	}
+	if records[0].NetIncome != 225514000 {
+		t.Fatalf("unexpected net income: %v", records[0].NetIncome)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 pages to be requested, got %d", calls)
+	}
+}
+
+func TestEastmoneyProviderFetchPagesStopsAtPagesCount(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		payload := eastmoneyPayload(1, eastmoneyRecord{SecurityCode: "2330", ReportDate: "2024-03-31", ParentNetProfit: eastmoneyNumber(100), BasicEPS: eastmoneyNumber(1.0)})
+		if err := json.NewEncoder(w).Encode(payload); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	provider := &EastmoneyProvider{Endpoint: srv.URL, PageSize: 1}
+	if _, err := provider.fetchQuarter(context.Background(), "2330", 2024, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected pagination to stop once pages count is reached, got %d calls", calls)
+	}
+}
+
+func TestEastmoneyProviderFetchQuarterUsesCache(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		payload := eastmoneyPayload(1, eastmoneyRecord{SecurityCode: "2330", ReportDate: "2024-03-31", ParentNetProfit: eastmoneyNumber(225514000), BasicEPS: eastmoneyNumber(8.70)})
+		if err := json.NewEncoder(w).Encode(payload); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	cache := &EastmoneyRawCache{Dir: t.TempDir()}
+	provider := &EastmoneyProvider{Endpoint: srv.URL, Cache: cache}
+
+	if _, err := provider.fetchQuarter(context.Background(), "2330", 2024, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := provider.fetchQuarter(context.Background(), "2330", 2024, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected cache to avoid a second remote call, got %d calls", calls)
+	}
+
+	if _, ok := cache.Load("2024-03-31"); !ok {
+		t.Fatalf("expected raw records to be cached under the report date")
+	}
+}
+
+func TestEastmoneyProviderFetchCoversMultipleYears(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reportDate := r.URL.Query().Get("filter")
+		reportDate = reportDate[len("(REPORTDATE='") : len(reportDate)-2]
+		payload := eastmoneyPayload(1, eastmoneyRecord{SecurityCode: "2330", ReportDate: reportDate, ParentNetProfit: eastmoneyNumber(100), BasicEPS: eastmoneyNumber(1.0)})
+		if err := json.NewEncoder(w).Encode(payload); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	provider := &EastmoneyProvider{
+		Endpoint:   srv.URL,
+		QuarterEnd: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		Years:      2,
+	}
+	result, err := provider.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceEastmoney {
+		t.Fatalf("expected source %s, got %s", SourceEastmoney, result.Source)
+	}
+	if len(result.Records) != 8 {
+		t.Fatalf("expected 8 quarterly records across 2 years, got %d", len(result.Records))
+	}
+}
+
+func TestEastmoneyProviderFetchRequiresStockNo(t *testing.T) {
+	provider := &EastmoneyProvider{}
+	if _, err := provider.Fetch(context.Background(), "  "); err == nil {
+		t.Fatalf("expected error for empty stockNo")
+	}
+}
+
+func TestEastmoneyProviderFetchQuarterlySatisfiesProvider(t *testing.T) {
+	var _ Provider = (*EastmoneyProvider)(nil)
+}
@@ -0,0 +1,145 @@
+package financials
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FetchCache 是 Service.Fetch 用於快取單次查詢結果的鍵值介面，以任意 bytes 搭配 TTL 運作，
+// 和批次同步用的 Cache (Load/Save 整批 QuarterlyReport) 是兩種不同用途的快取，彼此獨立。
+type FetchCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// SourceFetchCache 表示資料來自 Service.Fetch 的查詢快取 (FetchCache)，有別於批次同步快取
+// 使用的 SourceCache。
+const SourceFetchCache = "查詢快取"
+
+// DefaultFetchCacheTTL 為檢表查詢快取的預設存活時間。
+const DefaultFetchCacheTTL = 24 * time.Hour
+
+// fetchCacheSchemaVersion 隨 RawQuarterRecord/QuarterlyReport 欄位調整而遞增，確保快取鍵
+// 在資料結構變動時能乾淨失效，不會讀到舊版格式序列化的內容。
+const fetchCacheSchemaVersion = "v1"
+
+// fetchCacheKey 組出查詢快取使用的鍵值，包含端點、股票代號與結構版本。
+func fetchCacheKey(endpoint, stockNo string) string {
+	return fmt.Sprintf("financials:%s:%s:%s", fetchCacheSchemaVersion, endpoint, stockNo)
+}
+
+// cachedQuarterlyReports 為寫入 FetchCache 的快取內容，StoredAt 用於回報快取資料的年齡。
+type cachedQuarterlyReports struct {
+	StoredAt time.Time         `json:"stored_at"`
+	Records  []QuarterlyReport `json:"records"`
+}
+
+// fetchCacheDeleter 為可選的刪除介面，MemoryCache 與 RedisCache 皆有實作；Invalidate 找不到
+// 對應方法時，改以極短 TTL 覆寫既有值達到等效失效。
+type fetchCacheDeleter interface {
+	Delete(key string)
+}
+
+// MemoryCache 是以行程內記憶體實作的 FetchCache，適合開發環境或單一實例部署。
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache 建立空的 MemoryCache。
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get 取得快取值，不存在或已過期時回傳 false。
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set 寫入快取值，ttl<=0 表示永不過期。
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	c.entries[key] = memoryCacheEntry{value: value, expires: expires}
+}
+
+// Delete 移除指定鍵值，供 Service.Invalidate 使用。
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// fetchFromFetchCache 嘗試從 FetchCache 取得先前快取的查詢結果，命中時於 Note 註明快取的
+// 存放時間。
+func (s *twseProvider) fetchFromFetchCache(endpoint, stockNo string) (FetchResult, bool) {
+	raw, ok := s.FetchCache.Get(fetchCacheKey(endpoint, stockNo))
+	if !ok {
+		return FetchResult{}, false
+	}
+	var cached cachedQuarterlyReports
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return FetchResult{}, false
+	}
+	age := time.Since(cached.StoredAt).Round(time.Second)
+	return FetchResult{
+		Records: SortQuarterlyReports(cloneQuarterlyReports(cached.Records)),
+		Source:  SourceFetchCache,
+		Note:    fmt.Sprintf("資料來自查詢快取 (快取時間 %s 前)", age),
+	}, true
+}
+
+// storeFetchCache 將成功取得的檢表資料寫入 FetchCache，供下次查詢直接命中。
+func (s *twseProvider) storeFetchCache(endpoint, stockNo string, records []QuarterlyReport) {
+	if s.FetchCache == nil {
+		return
+	}
+	ttl := s.FetchCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultFetchCacheTTL
+	}
+	payload, err := json.Marshal(cachedQuarterlyReports{StoredAt: time.Now(), Records: records})
+	if err != nil {
+		return
+	}
+	s.FetchCache.Set(fetchCacheKey(endpoint, stockNo), payload, ttl)
+}
+
+// Invalidate 清除指定股票代號的查詢快取，適合在每月 10 日後 TWSE 公告窗口過後手動觸發，
+// 避免 Fetch 持續回傳已過期的快取資料。
+func (s *Service) Invalidate(stockNo string) {
+	if s.FetchCache == nil {
+		return
+	}
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	key := fetchCacheKey(endpoint, stockNo)
+	if deleter, ok := s.FetchCache.(fetchCacheDeleter); ok {
+		deleter.Delete(key)
+		return
+	}
+	s.FetchCache.Set(key, nil, time.Nanosecond)
+}
@@ -0,0 +1,71 @@
+package financials
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheGetSetExpiry(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("a", []byte("1"), time.Millisecond)
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Fatalf("expected immediate hit, got %q ok=%v", value, ok)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("expected entry to expire")
+	}
+}
+
+func TestMemoryCacheSetNoExpiry(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("a", []byte("1"), 0)
+	time.Sleep(5 * time.Millisecond)
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Fatalf("expected entry to persist without ttl, got %q ok=%v", value, ok)
+	}
+}
+
+func TestServiceStoreAndFetchFromFetchCache(t *testing.T) {
+	svc := &Service{Endpoint: "http://example.invalid", FetchCache: NewMemoryCache()}
+	provider := (*twseProvider)(svc)
+	provider.storeFetchCache(svc.Endpoint, "2330", []QuarterlyReport{
+		{CompanyCode: "2330", Year: 2024, Quarter: 1, NetIncome: 100, BasicEPS: 1.5},
+	})
+
+	result, ok := provider.fetchFromFetchCache(svc.Endpoint, "2330")
+	if !ok {
+		t.Fatalf("expected cache hit")
+	}
+	if result.Source != SourceFetchCache {
+		t.Fatalf("expected source %s, got %s", SourceFetchCache, result.Source)
+	}
+	if len(result.Records) != 1 || result.Records[0].NetIncome != 100 {
+		t.Fatalf("unexpected records: %+v", result.Records)
+	}
+	if !strings.Contains(result.Note, "快取") {
+		t.Fatalf("expected note to mention cache, got %q", result.Note)
+	}
+
+	if _, ok := provider.fetchFromFetchCache(svc.Endpoint, "2317"); ok {
+		t.Fatalf("expected cache miss for different stockNo")
+	}
+}
+
+func TestServiceInvalidateClearsFetchCache(t *testing.T) {
+	svc := &Service{Endpoint: "http://example.invalid", FetchCache: NewMemoryCache()}
+	provider := (*twseProvider)(svc)
+	provider.storeFetchCache(svc.Endpoint, "2330", []QuarterlyReport{
+		{CompanyCode: "2330", Year: 2024, Quarter: 1, NetIncome: 100, BasicEPS: 1.5},
+	})
+	if _, ok := provider.fetchFromFetchCache(svc.Endpoint, "2330"); !ok {
+		t.Fatalf("expected cache hit before invalidate")
+	}
+
+	svc.Invalidate("2330")
+
+	if _, ok := provider.fetchFromFetchCache(svc.Endpoint, "2330"); ok {
+		t.Fatalf("expected cache miss after invalidate")
+	}
+}
@@ -0,0 +1,105 @@
+package financials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMOPSEndpoint 為 MOPS 季度摘要查詢端點 (RPT_LICO_FN_CPD 樣式)。
+const DefaultMOPSEndpoint = "https://mopsov.twse.com.tw/mops/api/RPT_LICO_FN_CPD"
+
+// SourceMOPS 表示資料來自 MOPS 季度摘要查詢。
+const SourceMOPS = "MOPS 季度摘要"
+
+// DefaultMOPSPageSize 為 MOPSFetcher 分頁查詢時每頁預設筆數。
+const DefaultMOPSPageSize = 100
+
+// MOPSFetcher 透過 MOPS 季度摘要端點以分頁方式取得單一股票的檢表資料，沿用 BulkService
+// 下載全體檢表時相同的分頁邏輯：逐頁查詢直到空結果或回傳筆數小於 pageSize 為止。
+type MOPSFetcher struct {
+	Client   *http.Client
+	Endpoint string
+	PageSize int
+}
+
+// Fetch 分頁查詢 MOPS 季度摘要，回傳指定股票代號的檢表資料。
+func (f *MOPSFetcher) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	key := strings.TrimSpace(stockNo)
+	if key == "" {
+		return FetchResult{}, fmt.Errorf("stockNo 為必填")
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	endpoint := f.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultMOPSEndpoint
+	}
+	pageSize := f.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultMOPSPageSize
+	}
+
+	var all []QuarterlyReport
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return FetchResult{}, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return FetchResult{}, fmt.Errorf("建立 MOPS 請求失敗: %w", err)
+		}
+		q := req.URL.Query()
+		q.Set("SECURITY_CODE", key)
+		q.Set("pageNumber", strconv.Itoa(page))
+		q.Set("pageSize", strconv.Itoa(pageSize))
+		req.URL.RawQuery = q.Encode()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return FetchResult{}, fmt.Errorf("呼叫 MOPS 季度摘要 API 失敗: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return FetchResult{}, fmt.Errorf("讀取 MOPS 回傳內容失敗: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return FetchResult{}, fmt.Errorf("MOPS 季度摘要 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
+		}
+		var rawRecords []RawQuarterRecord
+		if err := json.Unmarshal(body, &rawRecords); err != nil {
+			return FetchResult{}, fmt.Errorf("解析 MOPS 季度摘要 JSON 失敗: %w", err)
+		}
+		if len(rawRecords) == 0 {
+			break
+		}
+		for _, rec := range rawRecords {
+			value, err := rec.Normalize()
+			if err != nil {
+				// 單筆格式錯誤不應中斷整批查詢，略過即可。
+				continue
+			}
+			all = append(all, value)
+		}
+		if len(rawRecords) < pageSize {
+			break
+		}
+	}
+
+	filtered := filterReportsByStock(all, key)
+	if len(filtered) == 0 {
+		return FetchResult{}, ErrNoData
+	}
+	return FetchResult{
+		Records: SortQuarterlyReports(filtered),
+		Source:  SourceMOPS,
+		Note:    "資料來自 MOPS 季度摘要查詢",
+	}, nil
+}
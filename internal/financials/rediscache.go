@@ -0,0 +1,46 @@
+package financials
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache 是以 Redis 實作的 FetchCache，可跨行程、跨實例共用查詢快取。
+type RedisCache struct {
+	Client *redis.Client
+	// Context 為選用的逾時/取消控制；未設定時使用 context.Background()。
+	Context context.Context
+}
+
+func (c *RedisCache) ctx() context.Context {
+	if c.Context != nil {
+		return c.Context
+	}
+	return context.Background()
+}
+
+// Get 取得快取值，找不到鍵值或連線失敗時回傳 false。
+func (c *RedisCache) Get(key string) ([]byte, bool) {
+	value, err := c.Client.Get(c.ctx(), key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set 寫入快取值，ttl<=0 表示永不過期。寫入失敗僅記錄訊息，不中斷呼叫端流程。
+func (c *RedisCache) Set(key string, value []byte, ttl time.Duration) {
+	if err := c.Client.Set(c.ctx(), key, value, ttl).Err(); err != nil {
+		log.Printf("寫入 Redis 查詢快取失敗: %v", err)
+	}
+}
+
+// Delete 移除指定鍵值，供 Service.Invalidate 使用。
+func (c *RedisCache) Delete(key string) {
+	if err := c.Client.Del(c.ctx(), key).Err(); err != nil {
+		log.Printf("刪除 Redis 查詢快取失敗: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package financials
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy 定義 Service.Fetch 呼叫遠端 API 失敗時的重試規則。MaxAttempts<=0 表示不
+// 重試，維持呼叫端原本只呼叫一次即回退至內建示例資料的行為。
+type RetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	Multiplier        float64
+	RetryableStatuses []int
+}
+
+// DefaultRetryPolicy 回傳保守的預設重試設定：最多 3 次嘗試、初始延遲 200ms、每次倍增 2
+// 倍、上限 2 秒，並將 429/502/503/504 視為可重試狀態碼。
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// maxAttempts 回傳有效的最大嘗試次數，未設定時視為只嘗試一次 (不重試)。
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// retryableStatus 判斷指定狀態碼是否應該重試；未設定 RetryableStatuses 時採用
+// DefaultRetryPolicy 的清單。
+func (p RetryPolicy) retryableStatus(status int) bool {
+	statuses := p.RetryableStatuses
+	if statuses == nil {
+		statuses = DefaultRetryPolicy().RetryableStatuses
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff 回傳第 attempt 次嘗試失敗後、下一次重試前的延遲時間 (attempt 從 1 起算)，依
+// Multiplier 指數成長並疊加隨機抖動以避免多個呼叫端同時重試造成的驚群效應，上限為
+// MaxBackoff。
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultRetryPolicy().InitialBackoff
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = DefaultRetryPolicy().Multiplier
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryPolicy().MaxBackoff
+	}
+	delay := float64(initial)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+	}
+	if delay > float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+	jittered := delay * (0.5 + rand.Float64()*0.5)
+	return time.Duration(jittered)
+}
+
+// waitBackoff 依 backoff 延遲等待下一次重試，若 ctx 先行結束則回傳其錯誤。
+func (p RetryPolicy) waitBackoff(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
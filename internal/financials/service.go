@@ -8,6 +8,9 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
 const DefaultEndpoint = "https://openapi.twse.com.tw/v1/opendata/t187ap08_E"
@@ -21,88 +24,220 @@ type FetchResult struct {
 	Note    string
 }
 
-// Service 代表檢表資料抓取器。
+// Provider 抽象化單一季度檢表資料來源，讓 Service.Fetch 可以依序嘗試多個來源並在
+// FetchResult.Source 記錄實際成功的來源；與 Fetcher 介面的差異僅在於方法名稱，語意與回傳
+// 型別相同，方便既有實作 (例如 EastmoneyProvider) 同時滿足兩者。
+type Provider interface {
+	FetchQuarterly(ctx context.Context, stockNo string) (FetchResult, error)
+}
+
+// DefaultProviderTimeout 為 Providers 中每個來源單次嘗試的預設逾時時間。
+const DefaultProviderTimeout = 10 * time.Second
+
+// Service 代表檢表資料抓取器，內建 TWSE 開放資料作為第一個來源，並依 Providers 的順序接續
+// 嘗試額外來源 (例如 Eastmoney)，所有來源皆失敗時回傳錯誤；內建示例資料的回退由
+// CompositeFetcher 鏈末端的 StaticFetcher 負責。
 type Service struct {
 	Client   *http.Client
 	Endpoint string
+	// Cache 為選用的批次同步快取，設定後會在呼叫 API 前先嘗試比對離線資料。
+	Cache Cache
+	// FetchCache 為選用的查詢結果快取，設定後會在 Cache 之前先比對單次查詢快取，命中時可省去
+	// 整批比對與遠端呼叫。
+	FetchCache FetchCache
+	// FetchCacheTTL 控制寫入 FetchCache 的存活時間，<=0 時使用 DefaultFetchCacheTTL。
+	FetchCacheTTL time.Duration
+	// Retry 控制呼叫遠端 API 失敗時的重試行為；零值 (MaxAttempts<=0) 表示不重試，只呼叫
+	// 一次，失敗即回傳錯誤。
+	Retry RetryPolicy
+	// Limiter 為選用的流量限制器，設定後每次嘗試前會先等待取得 token，對 TWSE API 更為友善。
+	Limiter *rate.Limiter
+	// Providers 為 TWSE 開放資料之外依序嘗試的備援來源 (例如 EastmoneyProvider)，未設定時
+	// Fetch 僅嘗試 TWSE 開放資料，失敗時直接回傳錯誤。
+	Providers []Provider
+	// ProviderTimeout 限制 Providers 中每個來源單次嘗試的時間，<=0 時使用
+	// DefaultProviderTimeout；TWSE 來源本身已有 Retry/Limiter 控制節奏，不受此逾時限制。
+	ProviderTimeout time.Duration
 }
 
-// Fetch 依股票代號取得稅後淨利資料。
+// Fetch 依股票代號取得稅後淨利資料：先嘗試內建的 TWSE 開放資料，失敗或回傳空結果後再依序
+// 嘗試 Providers 中設定的備援來源。Service 本身不回退至內建示例資料——Service 一律是
+// CompositeFetcher.Sources 中的一個來源，內建示例資料的回退統一交由該鏈末端的
+// StaticFetcher 負責，避免 Service 先一步「成功」而讓鏈上後續的來源永遠無法被嘗試到。
 func (s *Service) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
 	key := strings.TrimSpace(stockNo)
 	if key == "" {
 		return FetchResult{}, fmt.Errorf("stockNo 為必填")
 	}
+
+	var lastErr error
+	for _, provider := range s.providers() {
+		result, err := s.tryProvider(ctx, provider, key)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(result.Records) == 0 {
+			continue
+		}
+		return result, nil
+	}
+
+	if lastErr != nil {
+		return FetchResult{}, lastErr
+	}
+	return FetchResult{}, ErrNoData
+}
+
+// providers 回傳依序嘗試的來源：內建 TWSE 開放資料一律排在第一位，其後接上 Providers 設定
+// 的額外來源。
+func (s *Service) providers() []Provider {
+	providers := make([]Provider, 0, len(s.Providers)+1)
+	providers = append(providers, (*twseProvider)(s))
+	return append(providers, s.Providers...)
+}
+
+// tryProvider 呼叫單一來源；內建的 TWSE 來源已有自己的 Retry/Limiter 節奏控制，不額外套用
+// 逾時，其餘 Providers 則以 ProviderTimeout 限制單次嘗試時間，避免某個來源長時間無回應時拖
+// 慢整體查詢。
+func (s *Service) tryProvider(ctx context.Context, provider Provider, stockNo string) (FetchResult, error) {
+	if _, ok := provider.(*twseProvider); ok {
+		return provider.FetchQuarterly(ctx, stockNo)
+	}
+	timeout := s.ProviderTimeout
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+	pctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return provider.FetchQuarterly(pctx, stockNo)
+}
+
+// twseProvider 將 Service 既有欄位包裝成 Provider，實作 FetchQuarterly；底層型別與 Service
+// 相同，只是賦予不同的方法集，讓 Service 能把自己當成 providers() 清單中最優先的來源。
+type twseProvider Service
+
+// FetchQuarterly 呼叫 TWSE 開放資料 API 並視需要重試；純粹反映 TWSE 來源本身的結果，不處理
+// 內建示例資料回退，回退邏輯統一由 CompositeFetcher 鏈末端的 StaticFetcher 處理。
+func (s *twseProvider) FetchQuarterly(ctx context.Context, stockNo string) (FetchResult, error) {
 	endpoint := s.Endpoint
 	if endpoint == "" {
 		endpoint = DefaultEndpoint
 	}
+	if s.FetchCache != nil {
+		if result, ok := s.fetchFromFetchCache(endpoint, stockNo); ok {
+			return result, nil
+		}
+	}
+	if s.Cache != nil {
+		if result, ok := s.fetchFromCache(stockNo); ok {
+			return result, nil
+		}
+	}
 	client := s.Client
 	if client == nil {
 		client = http.DefaultClient
 	}
+
+	maxAttempts := s.Retry.maxAttempts()
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if s.Limiter != nil {
+			if err := s.Limiter.Wait(ctx); err != nil {
+				return FetchResult{}, err
+			}
+		}
+		result, retryable, err := s.fetchOnce(ctx, endpoint, client, stockNo, attempt)
+		if err == nil {
+			s.storeFetchCache(endpoint, stockNo, result.Records)
+			return result, nil
+		}
+		if !retryable {
+			return FetchResult{}, err
+		}
+		lastErr = err
+		if attempt == maxAttempts {
+			break
+		}
+		if err := s.Retry.waitBackoff(ctx, attempt); err != nil {
+			return FetchResult{}, err
+		}
+	}
+	return FetchResult{}, fmt.Errorf("呼叫檢表 API 失敗 (已嘗試 %d 次): %w", maxAttempts, lastErr)
+}
+
+// fetchOnce 呼叫遠端 API 一次並嘗試解析結果。retryable 僅在回傳 err 且值得重試 (網路錯誤或
+// Retry.RetryableStatuses 中的狀態碼) 時為 true。
+func (s *twseProvider) fetchOnce(ctx context.Context, endpoint string, client *http.Client, key string, attempt int) (FetchResult, bool, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return FetchResult{}, fmt.Errorf("建立請求失敗: %w", err)
+		return FetchResult{}, false, fmt.Errorf("建立請求失敗: %w", err)
 	}
-	static, hasStatic := LookupStaticEarnings(key)
 	resp, err := client.Do(req)
 	if err != nil {
-		if hasStatic {
-			return FetchResult{
-				Records: SortQuarterlyReports(cloneQuarterlyReports(static.Records)),
-				Source:  SourceFallback,
-				Note:    fmt.Sprintf("API 連線失敗，改用內建檢表: %v", err),
-			}, nil
-		}
-		return FetchResult{}, fmt.Errorf("呼叫檢表 API 失敗: %w", err)
+		return FetchResult{}, true, err
 	}
 	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		if hasStatic {
-			note := fmt.Sprintf("API 回傳狀態碼 %d，改用內建檢表", resp.StatusCode)
-			if len(body) > 0 {
-				note = fmt.Sprintf("%s: %s", note, string(body))
-			}
-			return FetchResult{
-				Records: SortQuarterlyReports(cloneQuarterlyReports(static.Records)),
-				Source:  SourceFallback,
-				Note:    note,
-			}, nil
+		if s.Retry.retryableStatus(resp.StatusCode) {
+			return FetchResult{}, true, fmt.Errorf("檢表 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
 		}
-		return FetchResult{}, fmt.Errorf("檢表 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
+		return FetchResult{}, false, fmt.Errorf("檢表 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
 	}
+
 	rawBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return FetchResult{}, fmt.Errorf("讀取檢表 API 回傳失敗: %w", err)
+		return FetchResult{}, false, fmt.Errorf("讀取檢表 API 回傳失敗: %w", err)
 	}
 	var rawRecords []RawQuarterRecord
 	if err := json.Unmarshal(rawBody, &rawRecords); err != nil {
-		return FetchResult{}, fmt.Errorf("解析檢表 JSON 失敗: %w", err)
+		return FetchResult{}, false, fmt.Errorf("解析檢表 JSON 失敗: %w", err)
 	}
 	filtered := FilterByStock(rawRecords, key)
 	if len(filtered) == 0 {
-		if hasStatic {
-			return FetchResult{
-				Records: SortQuarterlyReports(cloneQuarterlyReports(static.Records)),
-				Source:  SourceFallback,
-				Note:    "官方資料暫無該公司檢表，改用內建示例",
-			}, nil
-		}
-		return FetchResult{}, ErrNoData
+		return FetchResult{}, false, ErrNoData
 	}
 	normalized := make([]QuarterlyReport, 0, len(filtered))
 	for _, rec := range filtered {
 		value, err := rec.Normalize()
 		if err != nil {
-			return FetchResult{}, err
+			return FetchResult{}, false, err
 		}
 		normalized = append(normalized, value)
 	}
+	note := "資料來自台灣證券交易所檢表開放資料"
+	if attempt > 1 {
+		note = fmt.Sprintf("%s (第 %d 次嘗試成功)", note, attempt)
+	}
 	return FetchResult{
 		Records: SortQuarterlyReports(normalized),
 		Source:  SourceTWSE,
-		Note:    "資料來自台灣證券交易所檢表開放資料",
-	}, nil
+		Note:    note,
+	}, false, nil
+}
+
+// fetchFromCache 掃描批次同步快取中的每個年季，合併出指定股票代號的紀錄。
+func (s *twseProvider) fetchFromCache(stockNo string) (FetchResult, bool) {
+	keys, err := s.Cache.Keys()
+	if err != nil || len(keys) == 0 {
+		return FetchResult{}, false
+	}
+	var merged []QuarterlyReport
+	for _, key := range keys {
+		records, ok := s.Cache.Load(key)
+		if !ok {
+			continue
+		}
+		merged = append(merged, filterReportsByStock(records, stockNo)...)
+	}
+	if len(merged) == 0 {
+		return FetchResult{}, false
+	}
+	return FetchResult{
+		Records: SortQuarterlyReports(merged),
+		Source:  SourceCache,
+		Note:    "資料來自批次同步快取",
+	}, true
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -39,16 +40,87 @@ func TestServiceFetch(t *testing.T) {
 	}
 }
 
-func TestServiceFallback(t *testing.T) {
+func TestServiceFetchRetries(t *testing.T) {
+	payload := []RawQuarterRecord{
+		{
+			"公司代號":   "2330",
+			"年度":     "2024",
+			"季別":     "2",
+			"稅後淨利":   "236327000",
+			"基本每股盈餘": "9.0",
+		},
+	}
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(payload); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	svc := Service{
+		Endpoint: srv.URL,
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+			Multiplier:     2,
+		},
+	}
+	result, err := svc.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+	if result.Source != SourceTWSE {
+		t.Fatalf("expected source %s, got %s", SourceTWSE, result.Source)
+	}
+	if !strings.Contains(result.Note, "3") {
+		t.Fatalf("expected note to mention attempt count, got %q", result.Note)
+	}
+}
+
+func TestServiceFetchReturnsErrorWithoutFallingBackToStatic(t *testing.T) {
+	// Service 本身不再回退至內建示例資料 (即使 2317 有內建資料)；它一律是
+	// CompositeFetcher.Sources 中的一個來源，回退交由鏈末端的 StaticFetcher 負責，見
+	// composite_test.go 的 TestCompositeFetcherFallsBackToStatic。
 	svc := Service{Endpoint: "http://127.0.0.1:65535", Client: &http.Client{Timeout: 50 * time.Millisecond}}
-	result, err := svc.Fetch(context.Background(), "2317")
+	_, err := svc.Fetch(context.Background(), "2317")
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestServiceFetchDoesNotShadowLaterCompositeSources(t *testing.T) {
+	// 2317 有內建示例資料；若 Service.Fetch 仍在 TWSE 失敗後就地回退至該示例資料，
+	// CompositeFetcher 會把這次的「成功」當成最終結果，後面的來源永遠不會被嘗試到。
+	svc := &Service{Endpoint: "http://127.0.0.1:65535", Client: &http.Client{Timeout: 50 * time.Millisecond}}
+	calledNext := false
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			svc,
+			&financialsFetcherFunc{func(ctx context.Context, stockNo string) (FetchResult, error) {
+				calledNext = true
+				return FetchResult{Records: []QuarterlyReport{{CompanyCode: "2317", Year: 2024, Quarter: 1}}, Source: "下一個來源"}, nil
+			}},
+		},
+	}
+
+	result, err := composite.Fetch(context.Background(), "2317")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(result.Records) == 0 {
-		t.Fatalf("expected fallback records")
+	if !calledNext {
+		t.Fatalf("expected composite to reach the next configured source after twse fails")
 	}
-	if result.Source != SourceFallback {
-		t.Fatalf("unexpected source: %s", result.Source)
+	if result.Source != "下一個來源" {
+		t.Fatalf("expected result from next source, got %s", result.Source)
 	}
 }
@@ -17,6 +17,10 @@ type StaticEarnings struct {
 var staticEarnings = map[string]*StaticEarnings{
 	"2330": {
 		Records: []QuarterlyReport{
+			{CompanyCode: "2330", Year: 2022, Quarter: 1, NetIncome: 195376000, BasicEPS: 7.53},
+			{CompanyCode: "2330", Year: 2022, Quarter: 2, NetIncome: 237028000, BasicEPS: 9.14},
+			{CompanyCode: "2330", Year: 2022, Quarter: 3, NetIncome: 280870000, BasicEPS: 10.83},
+			{CompanyCode: "2330", Year: 2022, Quarter: 4, NetIncome: 295904000, BasicEPS: 11.41},
 			{CompanyCode: "2330", Year: 2023, Quarter: 1, NetIncome: 206991000, BasicEPS: 7.98},
 			{CompanyCode: "2330", Year: 2023, Quarter: 2, NetIncome: 181802000, BasicEPS: 7.01},
 			{CompanyCode: "2330", Year: 2023, Quarter: 3, NetIncome: 211089000, BasicEPS: 8.14},
@@ -27,6 +31,10 @@ var staticEarnings = map[string]*StaticEarnings{
 	},
 	"2317": {
 		Records: []QuarterlyReport{
+			{CompanyCode: "2317", Year: 2022, Quarter: 1, NetIncome: 14884600, BasicEPS: 1.07},
+			{CompanyCode: "2317", Year: 2022, Quarter: 2, NetIncome: 22920900, BasicEPS: 1.65},
+			{CompanyCode: "2317", Year: 2022, Quarter: 3, NetIncome: 38617700, BasicEPS: 2.79},
+			{CompanyCode: "2317", Year: 2022, Quarter: 4, NetIncome: 53634300, BasicEPS: 3.69},
 			{CompanyCode: "2317", Year: 2023, Quarter: 1, NetIncome: 20021400, BasicEPS: 1.46},
 			{CompanyCode: "2317", Year: 2023, Quarter: 2, NetIncome: 33214300, BasicEPS: 2.28},
 			{CompanyCode: "2317", Year: 2023, Quarter: 3, NetIncome: 48942500, BasicEPS: 3.36},
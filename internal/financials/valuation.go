@@ -0,0 +1,93 @@
+package financials
+
+import "fmt"
+
+// DefaultBondYieldBaseline 為葛拉漢修正公式中乘數所依據的歷史 AAA 公司債殖利率基準。
+const DefaultBondYieldBaseline = 4.4
+
+// FundamentalsSummary 彙總最近四季與前四季 QuarterlyReport 推算出的基本面數據。
+type FundamentalsSummary struct {
+	TTMNetIncome float64
+	TTMEPS       float64
+	// YoYEPSGrowth 為最近四季 TTM EPS 相較前四季 TTM EPS 的年增率 (例如 0.1 表示 10%)。
+	YoYEPSGrowth float64
+	// HasYoYGrowth 標示 reports 是否有足夠 (至少 8 季) 資料計算 YoYEPSGrowth。
+	HasYoYGrowth bool
+}
+
+// Fundamentals 彙總 reports 最近四季的 TTM 淨利與 TTM EPS；若另有前四季資料 (reports
+// 至少 8 季)，一併計算 TTM EPS 的年增率。reports 不足 4 季時回傳零值。
+func Fundamentals(reports []QuarterlyReport) FundamentalsSummary {
+	sorted := SortQuarterlyReports(reports)
+	if len(sorted) < 4 {
+		return FundamentalsSummary{}
+	}
+	latest := sorted[len(sorted)-4:]
+	var ttmNetIncome, ttmEPS float64
+	for _, r := range latest {
+		ttmNetIncome += r.NetIncome
+		ttmEPS += r.BasicEPS
+	}
+	summary := FundamentalsSummary{TTMNetIncome: ttmNetIncome, TTMEPS: ttmEPS}
+
+	if len(sorted) >= 8 {
+		prior := sorted[len(sorted)-8 : len(sorted)-4]
+		var priorEPS float64
+		for _, r := range prior {
+			priorEPS += r.BasicEPS
+		}
+		if priorEPS != 0 {
+			summary.YoYEPSGrowth = (ttmEPS - priorEPS) / priorEPS
+			summary.HasYoYGrowth = true
+		}
+	}
+	return summary
+}
+
+// MarginOfSafety 回傳 (intrinsic-price)/intrinsic 安全邊際比例；intrinsic 為 0 時回傳 0。
+func MarginOfSafety(price, intrinsic float64) float64 {
+	if intrinsic == 0 {
+		return 0
+	}
+	return (intrinsic - price) / intrinsic
+}
+
+// Valuation 以 QuarterlyReport 序列計算葛拉漢內在價值，讓檢表資料能直接走一條估值路徑，
+// 不需先轉換為 internal/valuation 套件的年度營收推估格式。
+type Valuation struct{}
+
+// IntrinsicValue 依葛拉漢修正公式計算內在價值：V = EPS * (8.5 + 2g) * 4.4 / Y。EPS 取
+// reports 最近四季加總的 TTM 基本每股盈餘，reports 不足 4 季時回傳錯誤。growthRate 為呼叫端
+// 指定的預期年成長率 (百分比，例如 10 表示 10%)；為 0 時改以 Fundamentals 推算的 TTM EPS
+// 年增率代入，兩者皆無法取得時視為 0 成長；成長率為負時同樣以 0 計算乘數，避免衰退季度
+// 產生低估甚至為負的內在價值。bondYield 為目前 AAA 公司債殖利率 (百分比)，為 0 時採用
+// DefaultBondYieldBaseline。
+func (Valuation) IntrinsicValue(reports []QuarterlyReport, growthRate, bondYield float64) (float64, error) {
+	sorted := SortQuarterlyReports(reports)
+	if len(sorted) < 4 {
+		return 0, fmt.Errorf("至少需要 4 季檢表資料才能計算 TTM EPS，目前僅有 %d 季", len(sorted))
+	}
+	latest := sorted[len(sorted)-4:]
+	var ttmEPS float64
+	for _, r := range latest {
+		ttmEPS += r.BasicEPS
+	}
+
+	growth := growthRate
+	if growth == 0 {
+		if summary := Fundamentals(sorted); summary.HasYoYGrowth {
+			growth = summary.YoYEPSGrowth * 100
+		}
+	}
+	if growth < 0 {
+		growth = 0
+	}
+
+	bondYieldPercent := bondYield
+	if bondYieldPercent == 0 {
+		bondYieldPercent = DefaultBondYieldBaseline
+	}
+
+	multiplier := 8.5 + 2*growth
+	return ttmEPS * multiplier * DefaultBondYieldBaseline / bondYieldPercent, nil
+}
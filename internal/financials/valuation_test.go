@@ -0,0 +1,131 @@
+package financials
+
+import (
+	"math"
+	"testing"
+)
+
+func buildQuarterlyReports(startYear int, eps []float64) []QuarterlyReport {
+	reports := make([]QuarterlyReport, len(eps))
+	for i, v := range eps {
+		year := startYear + i/4
+		quarter := i%4 + 1
+		reports[i] = QuarterlyReport{
+			CompanyCode: "TEST",
+			Year:        year,
+			Quarter:     quarter,
+			NetIncome:   v * 1000,
+			BasicEPS:    v,
+		}
+	}
+	return reports
+}
+
+func TestFundamentalsRequiresFourQuarters(t *testing.T) {
+	reports := buildQuarterlyReports(2023, []float64{1, 1, 1})
+	summary := Fundamentals(reports)
+	if summary.TTMEPS != 0 {
+		t.Fatalf("expected zero-value summary with fewer than 4 quarters, got %+v", summary)
+	}
+}
+
+func TestFundamentalsWithoutPriorYear(t *testing.T) {
+	reports := buildQuarterlyReports(2023, []float64{1, 1.5, 2, 2.5})
+	summary := Fundamentals(reports)
+	if math.Abs(summary.TTMEPS-7) > 1e-9 {
+		t.Fatalf("unexpected TTM EPS: %f", summary.TTMEPS)
+	}
+	if summary.HasYoYGrowth {
+		t.Fatalf("expected no YoY growth without 8 quarters of history")
+	}
+}
+
+func TestFundamentalsYoYGrowth(t *testing.T) {
+	reports := buildQuarterlyReports(2022, []float64{1, 1, 1, 1, 1.1, 1.1, 1.1, 1.1})
+	summary := Fundamentals(reports)
+	if !summary.HasYoYGrowth {
+		t.Fatalf("expected YoY growth to be computable with 8 quarters")
+	}
+	if math.Abs(summary.YoYEPSGrowth-0.1) > 1e-9 {
+		t.Fatalf("unexpected YoY EPS growth: %f", summary.YoYEPSGrowth)
+	}
+}
+
+func TestIntrinsicValueRequiresFourQuarters(t *testing.T) {
+	reports := buildQuarterlyReports(2023, []float64{1, 1, 1})
+	if _, err := (Valuation{}).IntrinsicValue(reports, 10, 4.4); err == nil {
+		t.Fatalf("expected an error with fewer than 4 quarters")
+	}
+}
+
+func TestIntrinsicValueWithExplicitGrowthRate(t *testing.T) {
+	reports := buildQuarterlyReports(2023, []float64{1, 1, 1, 1})
+	value, err := (Valuation{}).IntrinsicValue(reports, 10, 4.4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := 4.0 * (8.5 + 2*10) * 4.4 / 4.4
+	if math.Abs(value-expected) > 1e-6 {
+		t.Fatalf("unexpected intrinsic value: %f, want %f", value, expected)
+	}
+}
+
+func TestIntrinsicValueDerivesGrowthFromHistory(t *testing.T) {
+	reports := buildQuarterlyReports(2022, []float64{1, 1, 1, 1, 1.2, 1.2, 1.2, 1.2})
+	value, err := (Valuation{}).IntrinsicValue(reports, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := 4.8 * (8.5 + 2*20) * 4.4 / DefaultBondYieldBaseline
+	if math.Abs(value-expected) > 1e-6 {
+		t.Fatalf("unexpected intrinsic value: %f, want %f", value, expected)
+	}
+}
+
+func TestIntrinsicValueClampsNegativeGrowthToZero(t *testing.T) {
+	reports := buildQuarterlyReports(2023, []float64{1, 1, 1, 1})
+	value, err := (Valuation{}).IntrinsicValue(reports, -10, 4.4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := 4.0 * 8.5 * 4.4 / 4.4
+	if math.Abs(value-expected) > 1e-6 {
+		t.Fatalf("unexpected intrinsic value: %f, want %f", value, expected)
+	}
+}
+
+func TestIntrinsicValueClampsNegativeHistoricalGrowthToZero(t *testing.T) {
+	reports := buildQuarterlyReports(2022, []float64{1.2, 1.2, 1.2, 1.2, 1, 1, 1, 1})
+	value, err := (Valuation{}).IntrinsicValue(reports, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := 4.0 * 8.5 * 4.4 / DefaultBondYieldBaseline
+	if math.Abs(value-expected) > 1e-6 {
+		t.Fatalf("unexpected intrinsic value: %f, want %f", value, expected)
+	}
+}
+
+func TestMarginOfSafety(t *testing.T) {
+	if got := MarginOfSafety(80, 100); math.Abs(got-0.2) > 1e-9 {
+		t.Fatalf("unexpected margin of safety: %f", got)
+	}
+	if got := MarginOfSafety(80, 0); got != 0 {
+		t.Fatalf("expected zero margin of safety when intrinsic is zero, got %f", got)
+	}
+}
+
+func TestStaticEarningsHaveEnoughHistoryForValuation(t *testing.T) {
+	for _, stockNo := range []string{"2330", "2317"} {
+		earnings, ok := LookupStaticEarnings(stockNo)
+		if !ok {
+			t.Fatalf("expected static earnings for %s", stockNo)
+		}
+		if len(earnings.Records) < 8 {
+			t.Fatalf("expected at least 8 quarters of static earnings for %s, got %d", stockNo, len(earnings.Records))
+		}
+		if _, err := (Valuation{}).IntrinsicValue(earnings.Records, 0, 0); err != nil {
+			t.Fatalf("unexpected error computing intrinsic value for %s: %v", stockNo, err)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+// Package report 提供營收與檢表資料的 Excel (xlsx) 匯出與匯入，讓使用者可以將
+// TWSE 開放資料匯出成活頁簿、以人工方式註記後再匯入，經由既有的 Normalize() 流程
+// 重新產生結構化資料。revenue 與 financials 互不依賴，因此匯出入函式集中放在這個
+// 獨立套件，同時引用兩者的型別。
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+const (
+	sheetSummary   = "Summary"
+	sheetMonthly   = "Monthly"
+	sheetQuarterly = "Quarterly"
+	sheetYoY       = "YoY"
+)
+
+var monthlyHeaders = []string{
+	"公司代號", "公司名稱", "出表日期", "資料年月",
+	"營業收入-當月營收", "營業收入-當月累計營收", "營業收入-去年累計營收",
+	"營業收入-去年同月增減(%)", "備註",
+}
+
+var quarterlyHeaders = []string{"公司代號", "年度", "季別", "稅後淨利", "基本每股盈餘"}
+
+var yoyHeaders = []string{"年度", "月份", "當月營收", "去年同月營收", "YoY (%)"}
+
+// newHeaderStyle 建立粗體置中、淺色底色的表頭樣式。
+func newHeaderStyle(f *excelize.File) (int, error) {
+	return f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#D9E1F2"}, Pattern: 1},
+	})
+}
+
+// newThousandsStyle 建立千分位數字格式樣式，供金額欄位使用。
+func newThousandsStyle(f *excelize.File) (int, error) {
+	format := "#,##0"
+	return f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+}
+
+// writeHeaderRow 在 sheet 的第一列寫入表頭並套用樣式。
+func writeHeaderRow(f *excelize.File, sheet string, headers []string, style int) error {
+	for col, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(col+1, 1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetCellValue(sheet, cell, header); err != nil {
+			return err
+		}
+	}
+	last, err := excelize.CoordinatesToCellName(len(headers), 1)
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, "A1", last, style)
+}
+
+// monthKey 組出依年月索引資料所需的鍵值。
+func monthKey(year int, month time.Month) string {
+	return fmt.Sprintf("%d-%02d", year, int(month))
+}
+
+// WriteMonthlyRevenueXLSX 將月營收資料輸出為多分頁活頁簿 (Summary / Monthly / YoY)，
+// Monthly 分頁的表頭與 revenue.RawRecord 的 JSON 欄位名稱一致，可經 ReadMonthlyRevenueXLSX
+// 讀回並透過 RawRecord.Normalize 還原。
+func WriteMonthlyRevenueXLSX(w io.Writer, company *revenue.StaticCompany, records []revenue.MonthlyRevenue) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName("Sheet1", sheetSummary)
+	f.NewSheet(sheetMonthly)
+	f.NewSheet(sheetYoY)
+
+	headerStyle, err := newHeaderStyle(f)
+	if err != nil {
+		return fmt.Errorf("建立表頭樣式失敗: %w", err)
+	}
+	numberStyle, err := newThousandsStyle(f)
+	if err != nil {
+		return fmt.Errorf("建立數字格式失敗: %w", err)
+	}
+
+	stockNo, name := "", ""
+	if company != nil {
+		stockNo, name = company.StockNo, company.Name
+	}
+	if err := writeSummarySheet(f, headerStyle, [][2]string{
+		{"股票代號", stockNo},
+		{"公司名稱", name},
+		{"資料筆數", fmt.Sprintf("%d", len(records))},
+	}); err != nil {
+		return err
+	}
+
+	sorted := revenue.SortMonthlyRevenues(records)
+
+	// accumulated 儲存各年度截至當月的累計營收，供當月累計與去年累計欄位使用。
+	accumulated := make(map[string]float64, len(sorted))
+	var running float64
+	lastYear := 0
+	for _, rec := range sorted {
+		if rec.Year != lastYear {
+			running = 0
+			lastYear = rec.Year
+		}
+		running += rec.Revenue
+		accumulated[monthKey(rec.Year, rec.Month)] = running
+	}
+	byMonth := make(map[string]revenue.MonthlyRevenue, len(sorted))
+	for _, rec := range sorted {
+		byMonth[monthKey(rec.Year, rec.Month)] = rec
+	}
+
+	if err := writeHeaderRow(f, sheetMonthly, monthlyHeaders, headerStyle); err != nil {
+		return err
+	}
+	if err := writeHeaderRow(f, sheetYoY, yoyHeaders, headerStyle); err != nil {
+		return err
+	}
+
+	for i, rec := range sorted {
+		row := i + 2
+		prior, hasPrior := byMonth[monthKey(rec.Year-1, rec.Month)]
+		priorRevenue := 0.0
+		yoy := 0.0
+		if hasPrior {
+			priorRevenue = prior.Revenue
+			if prior.Revenue != 0 {
+				yoy = (rec.Revenue - prior.Revenue) / prior.Revenue * 100
+			}
+		}
+
+		monthlyValues := []interface{}{
+			stockNo,
+			name,
+			"",
+			fmt.Sprintf("%d%02d", rec.Year, int(rec.Month)),
+			rec.Revenue,
+			accumulated[monthKey(rec.Year, rec.Month)],
+			accumulated[monthKey(rec.Year-1, rec.Month)],
+			fmt.Sprintf("%.2f", yoy),
+			"",
+		}
+		for col, v := range monthlyValues {
+			cell, err := excelize.CoordinatesToCellName(col+1, row)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheetMonthly, cell, v); err != nil {
+				return err
+			}
+		}
+		revenueCell, _ := excelize.CoordinatesToCellName(5, row)
+		if err := f.SetCellStyle(sheetMonthly, revenueCell, revenueCell, numberStyle); err != nil {
+			return err
+		}
+
+		yoyValues := []interface{}{rec.Year, int(rec.Month), rec.Revenue, priorRevenue, yoy}
+		for col, v := range yoyValues {
+			cell, err := excelize.CoordinatesToCellName(col+1, row)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheetYoY, cell, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	f.SetActiveSheet(0)
+	return f.Write(w)
+}
+
+// WriteQuarterlyReportsXLSX 將季度損益資料輸出為多分頁活頁簿 (Summary / Quarterly)，
+// Quarterly 分頁的表頭與 financials.RawQuarterRecord.Value 查找的主要鍵值一致，可經
+// ReadQuarterlyReportsXLSX 讀回並透過 RawQuarterRecord.Normalize 還原。
+func WriteQuarterlyReportsXLSX(w io.Writer, code string, reports []financials.QuarterlyReport) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName("Sheet1", sheetSummary)
+	f.NewSheet(sheetQuarterly)
+
+	headerStyle, err := newHeaderStyle(f)
+	if err != nil {
+		return fmt.Errorf("建立表頭樣式失敗: %w", err)
+	}
+	numberStyle, err := newThousandsStyle(f)
+	if err != nil {
+		return fmt.Errorf("建立數字格式失敗: %w", err)
+	}
+
+	if err := writeSummarySheet(f, headerStyle, [][2]string{
+		{"公司代號", code},
+		{"資料筆數", fmt.Sprintf("%d", len(reports))},
+	}); err != nil {
+		return err
+	}
+
+	if err := writeHeaderRow(f, sheetQuarterly, quarterlyHeaders, headerStyle); err != nil {
+		return err
+	}
+
+	sorted := financials.SortQuarterlyReports(reports)
+	for i, rec := range sorted {
+		row := i + 2
+		companyCode := rec.CompanyCode
+		if companyCode == "" {
+			companyCode = code
+		}
+		values := []interface{}{companyCode, rec.Year, rec.Quarter, rec.NetIncome, rec.BasicEPS}
+		for col, v := range values {
+			cell, err := excelize.CoordinatesToCellName(col+1, row)
+			if err != nil {
+				return err
+			}
+			if err := f.SetCellValue(sheetQuarterly, cell, v); err != nil {
+				return err
+			}
+		}
+		netIncomeCell, _ := excelize.CoordinatesToCellName(4, row)
+		if err := f.SetCellStyle(sheetQuarterly, netIncomeCell, netIncomeCell, numberStyle); err != nil {
+			return err
+		}
+	}
+
+	f.SetActiveSheet(0)
+	return f.Write(w)
+}
+
+// writeSummarySheet 在 Summary 分頁寫入「欄位/內容」形式的摘要列表。
+func writeSummarySheet(f *excelize.File, headerStyle int, rows [][2]string) error {
+	if err := writeHeaderRow(f, sheetSummary, []string{"欄位", "內容"}, headerStyle); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		r := i + 2
+		if err := f.SetCellValue(sheetSummary, fmt.Sprintf("A%d", r), row[0]); err != nil {
+			return err
+		}
+		if err := f.SetCellValue(sheetSummary, fmt.Sprintf("B%d", r), row[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headerIndex 將表頭列轉換為欄位名稱到欄位索引的對照表。
+func headerIndex(header []string) map[string]int {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[h] = i
+	}
+	return index
+}
+
+// cellValue 依欄位名稱取得該列的儲存格內容，欄位不存在或超出範圍時回傳空字串。
+func cellValue(row []string, index map[string]int, name string) string {
+	i, ok := index[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+// ReadMonthlyRevenueXLSX 讀取由 WriteMonthlyRevenueXLSX 產生 (或手動依相同表頭編輯) 的
+// Monthly 分頁，還原為可直接交給 RawRecord.Normalize 使用的原始資料。
+func ReadMonthlyRevenueXLSX(r io.Reader) ([]revenue.RawRecord, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("開啟 Excel 檔案失敗: %w", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetMonthly)
+	if err != nil {
+		return nil, fmt.Errorf("讀取 %s 分頁失敗: %w", sheetMonthly, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	index := headerIndex(rows[0])
+
+	out := make([]revenue.RawRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		out = append(out, revenue.RawRecord{
+			CompanyCode:    cellValue(row, index, "公司代號"),
+			CompanyName:    cellValue(row, index, "公司名稱"),
+			PublishDate:    cellValue(row, index, "出表日期"),
+			DataMonth:      cellValue(row, index, "資料年月"),
+			MonthlyRevenue: cellValue(row, index, "營業收入-當月營收"),
+			AccRevenue:     cellValue(row, index, "營業收入-當月累計營收"),
+			AccRevenueLast: cellValue(row, index, "營業收入-去年累計營收"),
+			YoY:            cellValue(row, index, "營業收入-去年同月增減(%)"),
+			Note:           cellValue(row, index, "備註"),
+		})
+	}
+	return out, nil
+}
+
+// ReadQuarterlyReportsXLSX 讀取由 WriteQuarterlyReportsXLSX 產生 (或手動依相同表頭編輯) 的
+// Quarterly 分頁，還原為可直接交給 RawQuarterRecord.Normalize 使用的原始資料。
+func ReadQuarterlyReportsXLSX(r io.Reader) ([]financials.RawQuarterRecord, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("開啟 Excel 檔案失敗: %w", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(sheetQuarterly)
+	if err != nil {
+		return nil, fmt.Errorf("讀取 %s 分頁失敗: %w", sheetQuarterly, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	index := headerIndex(rows[0])
+
+	out := make([]financials.RawQuarterRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		out = append(out, financials.RawQuarterRecord{
+			"公司代號":   cellValue(row, index, "公司代號"),
+			"年度":     cellValue(row, index, "年度"),
+			"季別":     cellValue(row, index, "季別"),
+			"稅後淨利":   cellValue(row, index, "稅後淨利"),
+			"基本每股盈餘": cellValue(row, index, "基本每股盈餘"),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func TestWriteAndReadMonthlyRevenueXLSXRoundTrip(t *testing.T) {
+	company := &revenue.StaticCompany{StockNo: "2330", Name: "台積電"}
+	records := []revenue.MonthlyRevenue{
+		{Year: 2023, Month: 1, Revenue: 100},
+		{Year: 2024, Month: 1, Revenue: 150},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMonthlyRevenueXLSX(&buf, company, records); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := ReadMonthlyRevenueXLSX(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(raw) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(raw))
+	}
+
+	normalized := make([]revenue.MonthlyRevenue, 0, len(raw))
+	for _, rec := range raw {
+		value, err := rec.Normalize()
+		if err != nil {
+			t.Fatalf("normalize failed: %v", err)
+		}
+		normalized = append(normalized, value)
+	}
+	sorted := revenue.SortMonthlyRevenues(normalized)
+	if sorted[0].Year != 2023 || sorted[0].Revenue != 100 {
+		t.Fatalf("unexpected first record: %+v", sorted[0])
+	}
+	if sorted[1].Year != 2024 || sorted[1].Revenue != 150 {
+		t.Fatalf("unexpected second record: %+v", sorted[1])
+	}
+}
+
+func TestWriteAndReadQuarterlyReportsXLSXRoundTrip(t *testing.T) {
+	reports := []financials.QuarterlyReport{
+		{CompanyCode: "2330", Year: 2023, Quarter: 4, NetIncome: 2000, BasicEPS: 8.5},
+		{CompanyCode: "2330", Year: 2024, Quarter: 1, NetIncome: 2500, BasicEPS: 9.0},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteQuarterlyReportsXLSX(&buf, "2330", reports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := ReadQuarterlyReportsXLSX(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(raw) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(raw))
+	}
+
+	normalized := make([]financials.QuarterlyReport, 0, len(raw))
+	for _, rec := range raw {
+		value, err := rec.Normalize()
+		if err != nil {
+			t.Fatalf("normalize failed: %v", err)
+		}
+		normalized = append(normalized, value)
+	}
+	sorted := financials.SortQuarterlyReports(normalized)
+	if sorted[0].Year != 2023 || sorted[0].Quarter != 4 || sorted[0].NetIncome != 2000 {
+		t.Fatalf("unexpected first record: %+v", sorted[0])
+	}
+	if sorted[1].Year != 2024 || sorted[1].Quarter != 1 || sorted[1].BasicEPS != 9.0 {
+		t.Fatalf("unexpected second record: %+v", sorted[1])
+	}
+}
+
+func TestReadMonthlyRevenueXLSXEmptySheet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMonthlyRevenueXLSX(&buf, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, err := ReadMonthlyRevenueXLSX(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(raw) != 0 {
+		t.Fatalf("expected no rows, got %d", len(raw))
+	}
+}
@@ -0,0 +1,42 @@
+package revenue
+
+import (
+	"sort"
+	"strings"
+)
+
+// CompanyProvider 抽象化公司目錄查詢，讓呼叫端 (例如 server.App) 依賴介面而非
+// 套件層級函式，方便以外部檔案載入的目錄或測試替身取代內建示例資料。
+type CompanyProvider interface {
+	Lookup(stockNo string) (*StaticCompany, bool)
+	List() []*StaticCompany
+}
+
+// staticCompanyProvider 以記憶體中的 map 實作 CompanyProvider，供內建示例資料與
+// LoadCompanyProvider 載入的外部目錄共用。
+type staticCompanyProvider struct {
+	companies map[string]*StaticCompany
+}
+
+func (p *staticCompanyProvider) Lookup(stockNo string) (*StaticCompany, bool) {
+	key := strings.TrimSpace(stockNo)
+	if key == "" {
+		return nil, false
+	}
+	company, ok := p.companies[key]
+	return company, ok
+}
+
+func (p *staticCompanyProvider) List() []*StaticCompany {
+	out := make([]*StaticCompany, 0, len(p.companies))
+	for _, company := range p.companies {
+		out = append(out, company)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StockNo < out[j].StockNo })
+	return out
+}
+
+// DefaultCompanyProvider 回傳套件內建示例公司目錄的 CompanyProvider。
+func DefaultCompanyProvider() CompanyProvider {
+	return &staticCompanyProvider{companies: staticCompanies}
+}
@@ -0,0 +1,66 @@
+package revenue
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogEntry 對應外部公司目錄檔 (YAML 或 JSON) 中單一公司的欄位。
+type CatalogEntry struct {
+	StockNo      string            `yaml:"stock_no" json:"stock_no"`
+	Name         string            `yaml:"name" json:"name"`
+	Industry     string            `yaml:"industry" json:"industry"`
+	ICBCode      string            `yaml:"icb_code" json:"icb_code"`
+	Website      string            `yaml:"website" json:"website"`
+	Description  string            `yaml:"description" json:"description"`
+	FormDefaults map[string]string `yaml:"form_defaults" json:"form_defaults"`
+}
+
+// companyCatalogFile 為目錄檔的頂層結構，companies 為唯一必要欄位。
+type companyCatalogFile struct {
+	Companies []CatalogEntry `yaml:"companies" json:"companies"`
+}
+
+// LoadCompanyProvider 從 path 指定的 YAML 或 JSON 檔案載入公司目錄，回傳對應的
+// CompanyProvider。副檔名為 .json 時以 JSON 解析，否則一律視為 YAML。
+func LoadCompanyProvider(path string) (CompanyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("revenue: 讀取公司目錄檔失敗: %w", err)
+	}
+
+	var file companyCatalogFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("revenue: 解析公司目錄 JSON 失敗: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("revenue: 解析公司目錄 YAML 失敗: %w", err)
+		}
+	}
+
+	companies := make(map[string]*StaticCompany, len(file.Companies))
+	for _, entry := range file.Companies {
+		stockNo := strings.TrimSpace(entry.StockNo)
+		if stockNo == "" {
+			continue
+		}
+		companies[stockNo] = &StaticCompany{
+			StockNo:      stockNo,
+			Name:         entry.Name,
+			Industry:     entry.Industry,
+			ICBCode:      entry.ICBCode,
+			Website:      entry.Website,
+			Description:  entry.Description,
+			FormDefaults: entry.FormDefaults,
+		}
+	}
+
+	return &staticCompanyProvider{companies: companies}, nil
+}
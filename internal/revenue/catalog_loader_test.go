@@ -0,0 +1,77 @@
+package revenue
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCompanyProviderYAML(t *testing.T) {
+	dir := t.TempDir()
+	content := `companies:
+  - stock_no: "9999"
+    name: 測試股份有限公司
+    industry: 測試產業
+    icb_code: "1234"
+    website: https://example.test/
+    description: 用於單元測試的虛構公司。
+    form_defaults:
+      gross_margin: "10.0"
+`
+	path := filepath.Join(dir, "companies.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	provider, err := LoadCompanyProvider(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	company, ok := provider.Lookup("9999")
+	if !ok {
+		t.Fatalf("expected to find company 9999")
+	}
+	if company.Name != "測試股份有限公司" || company.ICBCode != "1234" {
+		t.Fatalf("unexpected company: %+v", company)
+	}
+	if len(provider.List()) != 1 {
+		t.Fatalf("expected a single company in the list, got %d", len(provider.List()))
+	}
+}
+
+func TestLoadCompanyProviderJSON(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"companies":[{"stock_no":"8888","name":"JSON 測試公司","industry":"測試產業"}]}`
+	path := filepath.Join(dir, "companies.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	provider, err := LoadCompanyProvider(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := provider.Lookup("8888"); !ok {
+		t.Fatalf("expected to find company 8888")
+	}
+}
+
+func TestLoadCompanyProviderMissingFile(t *testing.T) {
+	if _, err := LoadCompanyProvider(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatalf("expected an error for a missing catalog file")
+	}
+}
+
+func TestDefaultCompanyProviderMatchesStaticLookup(t *testing.T) {
+	provider := DefaultCompanyProvider()
+	company, ok := provider.Lookup("2330")
+	if !ok {
+		t.Fatalf("expected to find built-in company 2330")
+	}
+	if company.Name != staticCompanies["2330"].Name {
+		t.Fatalf("unexpected company: %+v", company)
+	}
+	if len(provider.List()) != len(staticCompanies) {
+		t.Fatalf("expected provider list to match built-in company count")
+	}
+}
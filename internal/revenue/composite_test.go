@@ -0,0 +1,91 @@
+package revenue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type stubRevenueFetcher struct {
+	result FetchResult
+	err    error
+}
+
+func (s *stubRevenueFetcher) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	return s.result, s.err
+}
+
+func TestCompositeFetcherSkipsEmptyResult(t *testing.T) {
+	want := FetchResult{
+		Records: []MonthlyRevenue{{Year: 2024, Month: time.January, Revenue: 100}},
+		Source:  "第二來源",
+	}
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			&stubRevenueFetcher{result: FetchResult{Source: "第一來源"}},
+			&stubRevenueFetcher{result: want},
+		},
+	}
+
+	result, err := composite.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != "第二來源" {
+		t.Fatalf("expected reported source to match winning fetcher, got %s", result.Source)
+	}
+	if len(result.Records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(result.Records))
+	}
+}
+
+func TestCompositeFetcherStopsOnContextCancellation(t *testing.T) {
+	called := false
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			&stubRevenueFetcher{err: errors.New("first source down")},
+			&fetcherFunc{func(ctx context.Context, stockNo string) (FetchResult, error) {
+				called = true
+				return FetchResult{}, nil
+			}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := composite.Fetch(ctx, "2330")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if called {
+		t.Fatalf("expected remaining sources to be skipped after cancellation")
+	}
+}
+
+func TestCompositeFetcherFallsBackToStatic(t *testing.T) {
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			&stubRevenueFetcher{err: errors.New("remote unavailable")},
+			StaticFetcher{},
+		},
+	}
+
+	result, err := composite.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceFallback {
+		t.Fatalf("expected fallback source, got %s", result.Source)
+	}
+}
+
+// fetcherFunc 將函式包裝成 Fetcher，方便在測試中斷言某個來源是否被呼叫到。
+type fetcherFunc struct {
+	fn func(ctx context.Context, stockNo string) (FetchResult, error)
+}
+
+func (f *fetcherFunc) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
+	return f.fn(ctx, stockNo)
+}
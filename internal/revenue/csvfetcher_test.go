@@ -0,0 +1,46 @@
+package revenue
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCSVFileFetcherReadsMatchingStock(t *testing.T) {
+	dir := t.TempDir()
+	content := "stock_no,year,month,revenue\n2330,2024,1,100\n2454,2024,1,999\n2330,2024,2,120\n"
+	if err := os.WriteFile(filepath.Join(dir, "revenue.csv"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	fetcher := &CSVFileFetcher{Dir: dir}
+	result, err := fetcher.Fetch(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceCSV {
+		t.Fatalf("expected source %s, got %s", SourceCSV, result.Source)
+	}
+	if len(result.Records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(result.Records))
+	}
+	if result.Records[0].Month != time.January || result.Records[1].Month != time.February {
+		t.Fatalf("records not sorted by month: %+v", result.Records)
+	}
+}
+
+func TestCSVFileFetcherNoMatchReturnsErrNoData(t *testing.T) {
+	dir := t.TempDir()
+	content := "stock_no,year,month,revenue\n2454,2024,1,999\n"
+	if err := os.WriteFile(filepath.Join(dir, "revenue.csv"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	fetcher := &CSVFileFetcher{Dir: dir}
+	_, err := fetcher.Fetch(context.Background(), "2330")
+	if err != ErrNoData {
+		t.Fatalf("expected ErrNoData, got %v", err)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
 const DefaultEndpoint = "https://openapi.twse.com.tw/v1/opendata/t187ap03_L"
@@ -29,14 +32,26 @@ type FetchResult struct {
 	Note    string
 }
 
-
 // Service 封裝對官方 API 的存取邏輯。
 type Service struct {
 	Client   *http.Client
 	Endpoint string
+	// FetchCache 為選用的查詢結果快取，設定後會在呼叫 API 前先嘗試比對快取資料。
+	FetchCache FetchCache
+	// FetchCacheTTL 控制寫入 FetchCache 的存活時間，<=0 時使用 DefaultFetchCacheTTL。
+	FetchCacheTTL time.Duration
+	// Retry 控制呼叫遠端 API 失敗時的重試行為；零值 (MaxAttempts<=0) 表示不重試，只呼叫
+	// 一次，失敗即回傳錯誤。
+	Retry RetryPolicy
+	// Limiter 為選用的流量限制器，設定後每次嘗試前會先等待取得 token，對 TWSE API 更為友善。
+	Limiter *rate.Limiter
 }
 
-// Fetch 取得指定股票代號的月營收。
+// Fetch 取得指定股票代號的月營收。遇到網路錯誤或 Retry.RetryableStatuses 中的狀態碼時，會
+// 依 Retry 設定的策略重試；非可重試的失敗或重試全部用盡後直接回傳錯誤。Service 本身不回退
+// 至內建示例資料——Service 一律是 CompositeFetcher.Sources 中的一個來源，內建示例資料的回
+// 退統一交由該鏈末端的 StaticFetcher 負責，避免 Service 先一步「成功」而讓鏈上後續的來源永
+// 遠無法被嘗試到。
 func (s *Service) Fetch(ctx context.Context, stockNo string) (FetchResult, error) {
 	key := strings.TrimSpace(stockNo)
 	if key == "" {
@@ -50,79 +65,93 @@ func (s *Service) Fetch(ctx context.Context, stockNo string) (FetchResult, error
 	if endpoint == "" {
 		endpoint = DefaultEndpoint
 	}
+	company, _ := LookupStaticCompany(key)
+
+	if s.FetchCache != nil {
+		if result, ok := s.fetchFromFetchCache(endpoint, key, company); ok {
+			return result, nil
+		}
+	}
+
+	maxAttempts := s.Retry.maxAttempts()
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if s.Limiter != nil {
+			if err := s.Limiter.Wait(ctx); err != nil {
+				return FetchResult{}, err
+			}
+		}
+		result, retryable, err := s.fetchOnce(ctx, endpoint, client, key, company, attempt)
+		if err == nil {
+			if result.Source == SourceTWSE {
+				s.storeFetchCache(endpoint, key, result.Records)
+			}
+			return result, nil
+		}
+		if !retryable {
+			return FetchResult{}, err
+		}
+		lastErr = err
+		if attempt == maxAttempts {
+			break
+		}
+		if err := s.Retry.waitBackoff(ctx, attempt); err != nil {
+			return FetchResult{}, err
+		}
+	}
+
+	return FetchResult{}, fmt.Errorf("呼叫營收 API 失敗 (已嘗試 %d 次): %w", maxAttempts, lastErr)
+}
+
+// fetchOnce 呼叫遠端 API 一次並嘗試解析結果。retryable 僅在回傳 err 且值得重試 (網路錯誤或
+// Retry.RetryableStatuses 中的狀態碼) 時為 true；err 為 nil 時 result 已是最終的 TWSE 回應。
+func (s *Service) fetchOnce(ctx context.Context, endpoint string, client *http.Client, key string, company *StaticCompany, attempt int) (FetchResult, bool, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return FetchResult{}, fmt.Errorf("建立請求失敗: %w", err)
+		return FetchResult{}, false, fmt.Errorf("建立請求失敗: %w", err)
 	}
-	company, hasCompany := LookupStaticCompany(key)
 	resp, err := client.Do(req)
 	if err != nil {
-		if hasCompany {
-			note := fmt.Sprintf("API 連線失敗，改用內建示例資料: %v", err)
-			return FetchResult{
-				Records: SortMonthlyRevenues(cloneMonthlyRecords(company.Records)),
-				Source:  SourceFallback,
-				Company: company,
-				Note:    note,
-			}, nil
-		}
-		return FetchResult{}, fmt.Errorf("呼叫營收 API 失敗: %w", err)
+		return FetchResult{}, true, err
 	}
 	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		if hasCompany {
-			note := fmt.Sprintf("API 回傳狀態碼 %d，改用內建示例資料", resp.StatusCode)
-			if len(body) > 0 {
-				note = fmt.Sprintf("%s: %s", note, string(body))
-			}
-			return FetchResult{
-				Records: SortMonthlyRevenues(cloneMonthlyRecords(company.Records)),
-				Source:  SourceFallback,
-				Company: company,
-				Note:    note,
-			}, nil
-		}
-		return FetchResult{}, fmt.Errorf("營收 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
+		return FetchResult{}, s.Retry.retryableStatus(resp.StatusCode), fmt.Errorf("營收 API 回傳狀態碼 %d: %s", resp.StatusCode, string(body))
 	}
+
 	rawBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return FetchResult{}, fmt.Errorf("讀取 API 回傳內容失敗: %w", err)
+		return FetchResult{}, false, fmt.Errorf("讀取 API 回傳內容失敗: %w", err)
 	}
 	var rawRecords []RawRecord
 	if err := json.Unmarshal(rawBody, &rawRecords); err != nil {
-		return FetchResult{}, fmt.Errorf("解析營收 JSON 失敗: %w", err)
+		return FetchResult{}, false, fmt.Errorf("解析營收 JSON 失敗: %w", err)
 	}
 	filtered := FilterByStock(rawRecords, key)
 	if len(filtered) == 0 {
-		if hasCompany {
-			return FetchResult{
-				Records: SortMonthlyRevenues(cloneMonthlyRecords(company.Records)),
-				Source:  SourceFallback,
-				Company: company,
-				Note:    "官方資料暫無該公司紀錄，改用內建示例資料",
-			}, nil
-		}
-		return FetchResult{}, ErrNoData
-
+		return FetchResult{}, false, ErrNoData
 	}
 	normalized := make([]MonthlyRevenue, 0, len(filtered))
 	for _, rec := range filtered {
 		value, err := rec.Normalize()
 		if err != nil {
-			return FetchResult{}, err
+			return FetchResult{}, false, err
 		}
 		normalized = append(normalized, value)
 	}
 	note := "資料來自台灣證券交易所開放資料"
-	if hasCompany {
+	if company != nil {
 		note += "；表單預設值會自動載入該公司常見範例"
 	}
+	if attempt > 1 {
+		note = fmt.Sprintf("%s (第 %d 次嘗試成功)", note, attempt)
+	}
 	return FetchResult{
 		Records: SortMonthlyRevenues(normalized),
 		Source:  SourceTWSE,
 		Company: company,
 		Note:    note,
-	}, nil
-
+	}, false, nil
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"testing"
+	"time"
 )
 
 func TestServiceFetch(t *testing.T) {
@@ -42,27 +43,57 @@ func TestServiceFetch(t *testing.T) {
 	}
 }
 
-func TestServiceFetchStatusError(t *testing.T) {
+func TestServiceFetchRetries(t *testing.T) {
+	calls := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusBadGateway)
-		w.Write([]byte("bad"))
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"公司代號":"2330","資料年月":"11201","營業收入-當月營收":"100"}]`))
 	}))
 	defer server.Close()
 
-	svc := &Service{Endpoint: server.URL}
+	svc := &Service{
+		Endpoint: server.URL,
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+			Multiplier:     2,
+		},
+	}
 	result, err := svc.Fetch(context.Background(), "2330")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if result.Source != SourceFallback {
-		t.Fatalf("expected fallback source, got %s", result.Source)
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
 	}
-	if result.Company == nil {
-		t.Fatalf("expected company info in fallback")
+	if result.Source != SourceTWSE {
+		t.Fatalf("expected source %s, got %s", SourceTWSE, result.Source)
+	}
+	if !strings.Contains(result.Note, "3") {
+		t.Fatalf("expected note to mention attempt count, got %q", result.Note)
 	}
-	if !strings.Contains(result.Note, "狀態碼") {
-		t.Fatalf("expected note to mention status code: %s", result.Note)
+}
 
+func TestServiceFetchStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("bad"))
+	}))
+	defer server.Close()
+
+	svc := &Service{Endpoint: server.URL}
+	_, err := svc.Fetch(context.Background(), "2330")
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "狀態碼") {
+		t.Fatalf("expected error to mention status code: %v", err)
 	}
 }
 
@@ -81,7 +112,7 @@ func TestServiceFetchNoData(t *testing.T) {
 	}
 }
 
-func TestServiceFetchFallbackWhenNoRecords(t *testing.T) {
+func TestServiceFetchNoDataEvenWithStaticCompany(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.Write([]byte(`[{"公司代號":"1101","資料年月":"11201","營業收入-當月營收":"100"}]`))
@@ -89,15 +120,35 @@ func TestServiceFetchFallbackWhenNoRecords(t *testing.T) {
 	defer server.Close()
 
 	svc := &Service{Endpoint: server.URL}
-	result, err := svc.Fetch(context.Background(), "2330")
+	_, err := svc.Fetch(context.Background(), "2330")
+	if !errors.Is(err, ErrNoData) {
+		t.Fatalf("expected ErrNoData, got %v", err)
+	}
+}
+
+func TestServiceFetchDoesNotShadowLaterCompositeSources(t *testing.T) {
+	// 2330 有內建示例資料；若 Service.Fetch 仍在 TWSE 失敗後就地回退至該示例資料，
+	// CompositeFetcher 會把這次的「成功」當成最終結果，後面的來源永遠不會被嘗試到。
+	svc := &Service{Endpoint: "http://127.0.0.1:65535", Client: &http.Client{Timeout: 50 * time.Millisecond}}
+	calledNext := false
+	composite := &CompositeFetcher{
+		Sources: []Fetcher{
+			svc,
+			&fetcherFunc{func(ctx context.Context, stockNo string) (FetchResult, error) {
+				calledNext = true
+				return FetchResult{Records: []MonthlyRevenue{{Year: 2024, Month: time.January, Revenue: 1}}, Source: "下一個來源"}, nil
+			}},
+		},
+	}
+
+	result, err := composite.Fetch(context.Background(), "2330")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if result.Source != SourceFallback {
-		t.Fatalf("expected fallback source, got %s", result.Source)
+	if !calledNext {
+		t.Fatalf("expected composite to reach the next configured source after twse fails")
 	}
-	if len(result.Records) == 0 {
-		t.Fatalf("expected fallback records")
+	if result.Source != "下一個來源" {
+		t.Fatalf("expected result from next source, got %s", result.Source)
 	}
 }
-
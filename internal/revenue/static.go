@@ -11,6 +11,7 @@ type StaticCompany struct {
 	StockNo      string
 	Name         string
 	Industry     string
+	ICBCode      string
 	Website      string
 	Description  string
 	FormDefaults map[string]string
@@ -22,6 +23,7 @@ var staticCompanies = map[string]*StaticCompany{
 		StockNo:  "2330",
 		Name:     "台灣積體電路製造股份有限公司",
 		Industry: "半導體",
+		ICBCode:  "9576",
 		Website:  "https://www.tsmc.com/",
 		Description: "台積電為全球晶圓代工龍頭，主要提供先進製程委外製造服務。" +
 			"以下內建資料整理自 2023-2024 年公開月營收公告 (單位：新台幣千元)。",
@@ -60,6 +62,7 @@ var staticCompanies = map[string]*StaticCompany{
 		StockNo:     "2317",
 		Name:        "鴻海精密工業股份有限公司",
 		Industry:    "電子 - 代工製造",
+		ICBCode:     "9578",
 		Website:     "https://www.foxconntech.com.tw/",
 		Description: "鴻海為全球最大電子代工與製造服務供應商，內建數據整理自 2023-2024 年公布月營收 (單位：新台幣千元)。",
 		FormDefaults: map[string]string{
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -11,11 +12,24 @@ import (
 	"strings"
 	"time"
 
+	"github.com/xuri/excelize/v2"
 	"github.com/yilab8/stock_auto_work/internal/financials"
 	"github.com/yilab8/stock_auto_work/internal/revenue"
 	"github.com/yilab8/stock_auto_work/internal/valuation"
+	"github.com/yilab8/stock_auto_work/internal/valuation/predict"
 )
 
+// defaultPredictRules 為首頁與匯出功能共用的月度 YoY 推算規則，依序由簡單到複雜嘗試：
+// 先沿用去年同月，再取過去三年同月平均，接著以近半年趨勢外推，最後才套用季節性分解。
+func defaultPredictRules() []predict.Rule {
+	return []predict.Rule{
+		predict.SameMonthLastYear(),
+		predict.MovingAverage(3),
+		predict.LinearTrend(6),
+		predict.SeasonalIndex(3),
+	}
+}
+
 // RevenueFetcher 抽象化營收服務，方便測試替換。
 type RevenueFetcher interface {
 	Fetch(ctx context.Context, stockNo string) (revenue.FetchResult, error)
@@ -31,39 +45,78 @@ type App struct {
 	Fetcher  RevenueFetcher
 	Earnings EarningsFetcher
 	Template *template.Template
-	now      func() time.Time
+	// Companies 提供公司目錄查詢，預設為內建示例資料，可由呼叫端 (例如
+	// cmd/server 依 -companies 旗標載入外部檔案後) 覆寫。
+	Companies revenue.CompanyProvider
+	// maxParallel 限制多股比較模式下同時抓取的標的數量，預設為 defaultMaxParallel。
+	maxParallel int
+	now         func() time.Time
 }
 
 // NewApp 建立 App 實例。
 func NewApp(fetcher RevenueFetcher, earnings EarningsFetcher, tmpl *template.Template) *App {
 	return &App{
-		Fetcher:  fetcher,
-		Earnings: earnings,
-		Template: tmpl,
-		now:      time.Now,
+		Fetcher:   fetcher,
+		Earnings:  earnings,
+		Template:  tmpl,
+		Companies: revenue.DefaultCompanyProvider(),
+		now:       time.Now,
 	}
 }
 
-// ServeHTTP 處理首頁請求。
+// companyProvider 回傳 a.Companies，未設定時退回內建示例目錄，避免直接以
+// App{} 建構 (例如測試替身) 時因 nil 介面造成 panic。
+func (a *App) companyProvider() revenue.CompanyProvider {
+	if a.Companies == nil {
+		return revenue.DefaultCompanyProvider()
+	}
+	return a.Companies
+}
+
+// ServeHTTP 依路徑分派至首頁、匯出或敏感度分析功能。
 func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if a.Template == nil {
-		http.Error(w, "template not configured", http.StatusInternalServerError)
+	switch r.URL.Path {
+	case "/export":
+		a.handleExport(w, r)
+		return
+	case "/sensitivity":
+		a.handleSensitivity(w, r)
+		return
+	case "/import":
+		a.handleImport(w, r)
 		return
 	}
+	a.handleHome(w, r)
+}
+
+// handleHome 處理首頁請求；當 stock_no 帶有多個以逗號分隔的股票代號時，改以比較模式呈現。
+func (a *App) handleHome(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	stockNo := strings.TrimSpace(query.Get("stock_no"))
 	if stockNo == "" {
 		stockNo = "2330"
 	}
-	baseCompany, _ := revenue.LookupStaticCompany(stockNo)
+	if tickers := splitStockNos(stockNo); len(tickers) > 1 {
+		a.handleComparison(w, r, tickers)
+		return
+	}
+
+	if a.Template == nil {
+		http.Error(w, "template not configured", http.StatusInternalServerError)
+		return
+	}
+	baseCompany, _ := a.companyProvider().Lookup(stockNo)
 	form := buildForm(query, baseCompany)
+	industryFilter := strings.TrimSpace(query.Get("industry"))
 
 	data := &pageData{
-		StockNo:      stockNo,
-		Year:         a.now().Year(),
-		Form:         form,
-		Company:      toCompanyView(baseCompany),
-		StockOptions: buildStockOptions(),
+		StockNo:          stockNo,
+		Year:             a.now().Year(),
+		Form:             form,
+		Company:          toCompanyView(baseCompany),
+		StockOptions:     buildStockOptions(a.companyProvider().List(), industryFilter),
+		Industries:       distinctIndustries(a.companyProvider().List()),
+		SelectedIndustry: industryFilter,
 	}
 
 	if a.Fetcher == nil {
@@ -115,15 +168,7 @@ func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var actualQuarters map[int]valuation.QuarterActual
 	if len(earningsResult.Records) > 0 {
-		actualQuarters = make(map[int]valuation.QuarterActual)
-		for _, record := range earningsResult.Records {
-			if record.Year == activeYear {
-				actualQuarters[record.Quarter] = valuation.QuarterActual{
-					NetIncome: record.NetIncome,
-					EPS:       record.BasicEPS,
-				}
-			}
-		}
+		actualQuarters = buildActualQuarters(earningsResult.Records, activeYear)
 		data.Earnings = buildEarningsView(earningsResult.Records, activeYear)
 		if latest, label := latestEPSReference(earningsResult.Records); latest > 0 {
 			data.EPSReference = fmt.Sprintf("%.2f", latest)
@@ -138,7 +183,7 @@ func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	manualYoY := parseYoYInputs(query)
 	assumptions := form.toAssumptions()
 	assumptions.ActualQuarters = actualQuarters
-	projection, err := valuation.BuildYearProjection(activeYear, grouped, manualYoY, assumptions)
+	projection, err := valuation.BuildYearProjection(activeYear, grouped, manualYoY, assumptions, defaultPredictRules()...)
 	if err != nil {
 		data.Error = err.Error()
 		a.render(w, data)
@@ -149,9 +194,125 @@ func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	data.Months = buildMonthViews(projection.Months, manualYoY)
 	data.Quarters = buildQuarterViews(projection.Quarters)
 	data.Summary = buildSummary(projection)
+
+	if periodRaw := strings.TrimSpace(query.Get("period")); periodRaw != "" {
+		mode, shiftBack := parsePeriod(periodRaw)
+		period, err := buildPeriodComparison(mode, shiftBack, activeYear, grouped, manualYoY, assumptions, earningsResult.Records, projection)
+		if err != nil {
+			data.PeriodError = err.Error()
+		} else {
+			data.Period = period
+		}
+	}
+
 	a.render(w, data)
 }
 
+// handleExport 重用與 handleHome 相同的 parseYoYInputs + fetcher + valuation.BuildYearProjection
+// 流程，改以 CSV 或 XLSX 檔案輸出推估結果，供使用者下載留存。format=monthly_xlsx/quarterly_xlsx
+// 則略過推估流程，改匯出可供人工校正後經 /import 重新匯入的原始資料活頁簿。
+func (a *App) handleExport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch strings.ToLower(query.Get("format")) {
+	case "monthly_xlsx":
+		a.handleMonthlyRevenueExport(w, r, query)
+		return
+	case "quarterly_xlsx":
+		a.handleQuarterlyReportExport(w, r, query)
+		return
+	}
+
+	stockNo, year, projection, assumptions, err := a.buildExportProjection(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch strings.ToLower(query.Get("format")) {
+	case "xlsx":
+		if err := writeProjectionXLSX(w, stockNo, year, projection, assumptions); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	default:
+		writeProjectionCSV(w, stockNo, year, projection, assumptions)
+	}
+}
+
+// buildExportProjection 複用首頁的資料擷取與估值流程，回傳匯出所需的推估結果與假設值。
+func (a *App) buildExportProjection(ctx context.Context, query url.Values) (string, int, valuation.YearProjection, valuation.Assumptions, error) {
+	stockNo, year, grouped, manualYoY, assumptions, err := a.buildAssumptionContext(ctx, query)
+	if err != nil {
+		return stockNo, year, valuation.YearProjection{}, assumptions, err
+	}
+	projection, err := valuation.BuildYearProjection(year, grouped, manualYoY, assumptions, defaultPredictRules()...)
+	if err != nil {
+		return stockNo, year, valuation.YearProjection{}, assumptions, err
+	}
+	return stockNo, year, projection, assumptions, nil
+}
+
+// buildAssumptionContext 擷取單一股票的營收/檢表資料並組出 BuildYearProjection 所需的
+// grouped、manualYoY 與 Assumptions，供 buildExportProjection 與敏感度分析等需要重複
+// 呼叫 BuildYearProjection 的功能共用，避免重複撰寫資料擷取流程。
+func (a *App) buildAssumptionContext(ctx context.Context, query url.Values) (string, int, map[int][]revenue.MonthlyRevenue, map[time.Month]float64, valuation.Assumptions, error) {
+	stockNo := strings.TrimSpace(query.Get("stock_no"))
+	if stockNo == "" {
+		stockNo = "2330"
+	}
+	if a.Fetcher == nil {
+		return stockNo, 0, nil, nil, valuation.Assumptions{}, fmt.Errorf("尚未設定資料來源")
+	}
+	baseCompany, _ := a.companyProvider().Lookup(stockNo)
+	form := buildForm(query, baseCompany)
+
+	result, err := a.Fetcher.Fetch(ctx, stockNo)
+	if err != nil {
+		return stockNo, 0, nil, nil, valuation.Assumptions{}, fmt.Errorf("取得營收資料失敗: %w", err)
+	}
+	if len(result.Records) == 0 {
+		return stockNo, 0, nil, nil, valuation.Assumptions{}, fmt.Errorf("取得的營收資料為空")
+	}
+	if result.Company != nil {
+		form = buildForm(query, result.Company)
+	}
+
+	var earningsResult financials.FetchResult
+	if a.Earnings != nil {
+		if res, err := a.Earnings.Fetch(ctx, stockNo); err == nil {
+			earningsResult = res
+		}
+	}
+
+	grouped := revenue.GroupByYear(result.Records)
+	years := make([]int, 0, len(grouped))
+	for y := range grouped {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	activeYear := determineYear(query, years, a.now().Year())
+
+	var actualQuarters map[int]valuation.QuarterActual
+	if len(earningsResult.Records) > 0 {
+		actualQuarters = make(map[int]valuation.QuarterActual)
+		for _, record := range earningsResult.Records {
+			if record.Year == activeYear {
+				actualQuarters[record.Quarter] = valuation.QuarterActual{
+					NetIncome: record.NetIncome,
+					EPS:       record.BasicEPS,
+				}
+			}
+		}
+		if latest, _ := latestEPSReference(earningsResult.Records); latest > 0 && query.Get("prev_eps") == "" {
+			form.PrevQuarterEPS = fmt.Sprintf("%.2f", latest)
+		}
+	}
+
+	manualYoY := parseYoYInputs(query)
+	assumptions := form.toAssumptions()
+	assumptions.ActualQuarters = actualQuarters
+	return stockNo, activeYear, grouped, manualYoY, assumptions, nil
+}
+
 func (a *App) render(w http.ResponseWriter, data *pageData) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := a.Template.Execute(w, data); err != nil {
@@ -178,6 +339,12 @@ type pageData struct {
 	EPSReference      string
 	EPSReferenceLabel string
 	Error             string
+	Comparison        *ComparisonView
+	Period            *PeriodComparisonView
+	PeriodError       string
+	Industries        []string
+	SelectedIndustry  string
+	Sensitivity       *SensitivityView
 }
 
 type stockOption struct {
@@ -256,6 +423,8 @@ type monthView struct {
 	ReferenceMoMPercent  float64
 	ReferenceRevenue     float64
 	HasReference         bool
+	// RuleUsed 為推估此月份 YoY 所採用的 predict.Rule 名稱，已公告月份則為空字串。
+	RuleUsed string
 }
 
 type quarterView struct {
@@ -287,65 +456,87 @@ type summaryView struct {
 	AvgMoMPercent  float64
 }
 
+// buildMonthViews 為渲染邊界：將 valuation.MonthEstimate 的 decimal.Decimal 欄位轉換為
+// float64 供樣板與匯出使用，推估計算本身仍在 internal/valuation 以 decimal 進行。
 func buildMonthViews(months []valuation.MonthEstimate, manual map[time.Month]float64) []monthView {
 	views := make([]monthView, 0, len(months))
 	for _, m := range months {
 		name := fmt.Sprintf("yoy_%02d", int(m.Month))
 		id := fmt.Sprintf("input-%02d", int(m.Month))
-		inputValue := fmt.Sprintf("%.2f", m.YoY*100)
+		yoy, _ := m.YoY.Float64()
+		inputValue := fmt.Sprintf("%.2f", yoy*100)
 		if v, ok := manual[m.Month]; ok {
 			inputValue = fmt.Sprintf("%.2f", v*100)
 		}
+		mom, _ := m.MoM.Float64()
+		referenceYoY, _ := m.ReferenceYoY.Float64()
+		referenceMoM, _ := m.ReferenceMoM.Float64()
+		previousRevenue, _ := m.PreviousRevenue.Float64()
+		previousMonthRevenue, _ := m.PreviousMonthRevenue.Float64()
+		revenue, _ := m.Revenue.Float64()
+		referenceRevenue, _ := m.ReferenceRevenue.Float64()
 		views = append(views, monthView{
 			Index:                int(m.Month),
 			Label:                fmt.Sprintf("%02d月", int(m.Month)),
-			PreviousRevenue:      m.PreviousRevenue,
-			PreviousMonthRevenue: m.PreviousMonthRevenue,
-			Revenue:              m.Revenue,
-			YoYPercent:           m.YoY * 100,
-			MoMPercent:           m.MoM * 100,
+			PreviousRevenue:      previousRevenue,
+			PreviousMonthRevenue: previousMonthRevenue,
+			Revenue:              revenue,
+			YoYPercent:           yoy * 100,
+			MoMPercent:           mom * 100,
 			IsActual:             m.IsActual,
 			InputName:            name,
 			InputValue:           inputValue,
 			Editable:             !m.IsActual,
 			InputID:              id,
-			ReferenceYoYPercent:  m.ReferenceYoY * 100,
-			ReferenceMoMPercent:  m.ReferenceMoM * 100,
-			ReferenceRevenue:     m.ReferenceRevenue,
+			ReferenceYoYPercent:  referenceYoY * 100,
+			ReferenceMoMPercent:  referenceMoM * 100,
+			ReferenceRevenue:     referenceRevenue,
 			HasReference:         !m.IsActual && m.HasReference,
+			RuleUsed:             m.RuleUsed,
 		})
 	}
 	return views
 }
 
+// buildQuarterViews 為渲染邊界，轉換規則同 buildMonthViews。
 func buildQuarterViews(quarters []valuation.QuarterBreakdown) []quarterView {
 	views := make([]quarterView, 0, len(quarters))
 	for _, q := range quarters {
+		revenue, _ := q.Revenue.Float64()
+		grossProfit, _ := q.GrossProfit.Float64()
+		operatingIncome, _ := q.OperatingIncome.Float64()
+		preTaxIncome, _ := q.PreTaxIncome.Float64()
+		netIncome, _ := q.NetIncome.Float64()
+		eps, _ := q.EPS.Float64()
 		views = append(views, quarterView{
 			Quarter:         q.Quarter,
-			Revenue:         q.Revenue,
-			GrossProfit:     q.GrossProfit,
-			OperatingIncome: q.OperatingIncome,
-			PreTaxIncome:    q.PreTaxIncome,
-			NetIncome:       q.NetIncome,
-			EPS:             q.EPS,
+			Revenue:         revenue,
+			GrossProfit:     grossProfit,
+			OperatingIncome: operatingIncome,
+			PreTaxIncome:    preTaxIncome,
+			NetIncome:       netIncome,
+			EPS:             eps,
 			IsActual:        q.IsActual,
 		})
 	}
 	return views
 }
 
+// buildSummary 為渲染邊界，轉換規則同 buildMonthViews。
 func buildSummary(p valuation.YearProjection) summaryView {
-	upsidePercent := p.Upside * 100
-	avgYoYPercent := p.AvgYoY * 100
-	avgMoMPercent := p.AvgMoM * 100
+	annualRevenue, _ := p.AnnualRevenue.Float64()
+	annualEPS, _ := p.AnnualEPS.Float64()
+	estimatedPrice, _ := p.EstimatedPrice.Float64()
+	upside, _ := p.Upside.Float64()
+	avgYoY, _ := p.AvgYoY.Float64()
+	avgMoM, _ := p.AvgMoM.Float64()
 	return summaryView{
-		AnnualRevenue:  p.AnnualRevenue,
-		AnnualEPS:      p.AnnualEPS,
-		EstimatedPrice: p.EstimatedPrice,
-		UpsidePercent:  upsidePercent,
-		AvgYoYPercent:  avgYoYPercent,
-		AvgMoMPercent:  avgMoMPercent,
+		AnnualRevenue:  annualRevenue,
+		AnnualEPS:      annualEPS,
+		EstimatedPrice: estimatedPrice,
+		UpsidePercent:  upside * 100,
+		AvgYoYPercent:  avgYoY * 100,
+		AvgMoMPercent:  avgMoM * 100,
 	}
 }
 
@@ -389,16 +580,34 @@ func latestEPSReference(records []financials.QuarterlyReport) (float64, string)
 	return latest.BasicEPS, fmt.Sprintf("%dQ%d", latest.Year, latest.Quarter)
 }
 
-func buildStockOptions() []stockOption {
-	companies := revenue.StaticCompanyList()
+// buildStockOptions 將公司目錄轉為下拉選單選項，industryFilter 非空時僅保留該產業的公司。
+func buildStockOptions(companies []*revenue.StaticCompany, industryFilter string) []stockOption {
 	options := make([]stockOption, 0, len(companies))
 	for _, company := range companies {
+		if industryFilter != "" && company.Industry != industryFilter {
+			continue
+		}
 		label := fmt.Sprintf("%s - %s", company.StockNo, company.Name)
 		options = append(options, stockOption{Code: company.StockNo, Label: label})
 	}
 	return options
 }
 
+// distinctIndustries 取得公司目錄中出現過的產業別 (依字母排序)，供前端篩選下拉選單使用。
+func distinctIndustries(companies []*revenue.StaticCompany) []string {
+	seen := make(map[string]bool)
+	industries := make([]string, 0, len(companies))
+	for _, company := range companies {
+		if company.Industry == "" || seen[company.Industry] {
+			continue
+		}
+		seen[company.Industry] = true
+		industries = append(industries, company.Industry)
+	}
+	sort.Strings(industries)
+	return industries
+}
+
 func toCompanyView(company *revenue.StaticCompany) *companyView {
 	if company == nil {
 		return nil
@@ -499,3 +708,131 @@ func parseYoYInputs(values url.Values) map[time.Month]float64 {
 	}
 	return result
 }
+
+func exportFilename(stockNo string, year int, ext string) string {
+	return fmt.Sprintf("%s_%d_projection.%s", stockNo, year, ext)
+}
+
+// writeProjectionCSV 輸出月度營收推估與季度損益表，並附上摘要列。
+func writeProjectionCSV(w http.ResponseWriter, stockNo string, year int, p valuation.YearProjection, asm valuation.Assumptions) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exportFilename(stockNo, year, "csv")))
+
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	cw.Write([]string{"Month", "Label", "Revenue", "YoY", "MoM", "IsActual", "ReferenceRevenue"})
+	for _, m := range p.Months {
+		revenue, _ := m.Revenue.Float64()
+		yoy, _ := m.YoY.Float64()
+		mom, _ := m.MoM.Float64()
+		referenceRevenue, _ := m.ReferenceRevenue.Float64()
+		cw.Write([]string{
+			strconv.Itoa(int(m.Month)),
+			fmt.Sprintf("%02d月", int(m.Month)),
+			formatExportFloat(revenue),
+			formatExportFloat(yoy * 100),
+			formatExportFloat(mom * 100),
+			strconv.FormatBool(m.IsActual),
+			formatExportFloat(referenceRevenue),
+		})
+	}
+
+	cw.Write([]string{})
+	cw.Write([]string{"Quarter", "NetIncome", "EPS", "IsActual"})
+	for _, q := range p.Quarters {
+		netIncome, _ := q.NetIncome.Float64()
+		eps, _ := q.EPS.Float64()
+		cw.Write([]string{
+			strconv.Itoa(q.Quarter),
+			formatExportFloat(netIncome),
+			formatExportFloat(eps),
+			strconv.FormatBool(q.IsActual),
+		})
+	}
+
+	avgYoY, _ := p.AvgYoY.Float64()
+	avgMoM, _ := p.AvgMoM.Float64()
+	estimatedPrice, _ := p.EstimatedPrice.Float64()
+	cw.Write([]string{})
+	cw.Write([]string{"AvgYoY", "AvgMoM", "EstimatedPrice", "CurrentPrice"})
+	cw.Write([]string{
+		formatExportFloat(avgYoY * 100),
+		formatExportFloat(avgMoM * 100),
+		formatExportFloat(estimatedPrice),
+		formatExportFloat(asm.CurrentPrice),
+	})
+}
+
+// writeProjectionXLSX 輸出與 writeProjectionCSV 相同的資料，但以 Excel 活頁簿呈現。
+func writeProjectionXLSX(w http.ResponseWriter, stockNo string, year int, p valuation.YearProjection, asm valuation.Assumptions) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	const monthSheet = "月度營收推估"
+	const quarterSheet = "季度損益"
+	f.SetSheetName("Sheet1", monthSheet)
+	f.NewSheet(quarterSheet)
+
+	monthHeaders := []string{"Month", "Label", "Revenue", "YoY", "MoM", "IsActual", "ReferenceRevenue"}
+	for col, header := range monthHeaders {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(monthSheet, cell, header)
+	}
+	for i, m := range p.Months {
+		row := i + 2
+		revenue, _ := m.Revenue.Float64()
+		yoy, _ := m.YoY.Float64()
+		mom, _ := m.MoM.Float64()
+		referenceRevenue, _ := m.ReferenceRevenue.Float64()
+		values := []interface{}{
+			int(m.Month),
+			fmt.Sprintf("%02d月", int(m.Month)),
+			revenue,
+			yoy * 100,
+			mom * 100,
+			m.IsActual,
+			referenceRevenue,
+		}
+		for col, v := range values {
+			cell, _ := excelize.CoordinatesToCellName(col+1, row)
+			f.SetCellValue(monthSheet, cell, v)
+		}
+	}
+
+	quarterHeaders := []string{"Quarter", "NetIncome", "EPS", "IsActual"}
+	for col, header := range quarterHeaders {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(quarterSheet, cell, header)
+	}
+	for i, q := range p.Quarters {
+		row := i + 2
+		netIncome, _ := q.NetIncome.Float64()
+		eps, _ := q.EPS.Float64()
+		values := []interface{}{q.Quarter, netIncome, eps, q.IsActual}
+		for col, v := range values {
+			cell, _ := excelize.CoordinatesToCellName(col+1, row)
+			f.SetCellValue(quarterSheet, cell, v)
+		}
+	}
+	avgYoY, _ := p.AvgYoY.Float64()
+	avgMoM, _ := p.AvgMoM.Float64()
+	estimatedPrice, _ := p.EstimatedPrice.Float64()
+	summaryRow := len(p.Quarters) + 3
+	f.SetCellValue(quarterSheet, fmt.Sprintf("A%d", summaryRow), "AvgYoY")
+	f.SetCellValue(quarterSheet, fmt.Sprintf("B%d", summaryRow), avgYoY*100)
+	f.SetCellValue(quarterSheet, fmt.Sprintf("C%d", summaryRow), "AvgMoM")
+	f.SetCellValue(quarterSheet, fmt.Sprintf("D%d", summaryRow), avgMoM*100)
+	f.SetCellValue(quarterSheet, fmt.Sprintf("A%d", summaryRow+1), "EstimatedPrice")
+	f.SetCellValue(quarterSheet, fmt.Sprintf("B%d", summaryRow+1), estimatedPrice)
+	f.SetCellValue(quarterSheet, fmt.Sprintf("C%d", summaryRow+1), "CurrentPrice")
+	f.SetCellValue(quarterSheet, fmt.Sprintf("D%d", summaryRow+1), asm.CurrentPrice)
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exportFilename(stockNo, year, "xlsx")))
+	return f.Write(w)
+}
+
+func formatExportFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/yilab8/stock_auto_work/internal/valuation"
+)
+
+// defaultMaxParallel 為多股比較模式下未設定 maxParallel 時使用的預設同時抓取數量。
+const defaultMaxParallel = 4
+
+// ComparisonView 彙整多檔股票並列比較所需的各標的推估結果。
+type ComparisonView struct {
+	Tickers []TickerProjection
+}
+
+// TickerProjection 為單一標的在比較模式下的推估結果；Error 非空時代表該標的取得失敗，
+// 但不影響其他標的繼續呈現 (partial success)。
+type TickerProjection struct {
+	StockNo      string
+	Company      *companyView
+	Year         int
+	Projection   *valuation.YearProjection
+	Months       []monthView
+	Quarters     []quarterView
+	Summary      summaryView
+	CurrentPrice float64
+	Error        string
+}
+
+func (a *App) maxParallelOrDefault() int {
+	if a.maxParallel > 0 {
+		return a.maxParallel
+	}
+	return defaultMaxParallel
+}
+
+// splitStockNos 將逗號分隔的股票代號字串拆成清單，並去除空白與空白項目。
+func splitStockNos(raw string) []string {
+	parts := strings.Split(raw, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+// handleComparison 並行抓取每檔股票的營收/檢表資料並組出年度推估，單一標的失敗時只會
+// 記錄在該標的的 Error 欄位，不會讓整個請求失敗。
+func (a *App) handleComparison(w http.ResponseWriter, r *http.Request, tickers []string) {
+	query := r.URL.Query()
+	ctx := r.Context()
+
+	sem := make(chan struct{}, a.maxParallelOrDefault())
+	results := make([]TickerProjection, len(tickers))
+	g := new(errgroup.Group)
+	for i, ticker := range tickers {
+		i, ticker := i, ticker
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = a.buildTickerProjection(ctx, ticker, query)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	comparison := ComparisonView{Tickers: results}
+
+	if wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if err := json.NewEncoder(w).Encode(comparison); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if a.Template == nil {
+		http.Error(w, "template not configured", http.StatusInternalServerError)
+		return
+	}
+	data := &pageData{
+		StockNo:      strings.Join(tickers, ","),
+		Year:         a.now().Year(),
+		StockOptions: buildStockOptions(a.companyProvider().List(), ""),
+		Comparison:   &comparison,
+	}
+	a.render(w, data)
+}
+
+// buildTickerProjection 重用 buildExportProjection 的資料擷取流程計算單一標的的年度推估，
+// 讓比較模式與匯出功能共用完全相同的取數邏輯，並套用相同的手動 YoY 覆寫。
+func (a *App) buildTickerProjection(ctx context.Context, ticker string, query url.Values) TickerProjection {
+	tp := TickerProjection{StockNo: ticker}
+	tickerQuery := cloneValuesWithStock(query, ticker)
+
+	_, year, projection, assumptions, err := a.buildExportProjection(ctx, tickerQuery)
+	if err != nil {
+		tp.Error = err.Error()
+		return tp
+	}
+
+	baseCompany, _ := a.companyProvider().Lookup(ticker)
+	tp.Company = toCompanyView(baseCompany)
+	tp.Year = year
+	tp.Projection = &projection
+	tp.Months = buildMonthViews(projection.Months, parseYoYInputs(tickerQuery))
+	tp.Quarters = buildQuarterViews(projection.Quarters)
+	tp.Summary = buildSummary(projection)
+	tp.CurrentPrice = assumptions.CurrentPrice
+	return tp
+}
+
+// cloneValuesWithStock 複製查詢參數並覆寫 stock_no，讓比較模式能對每檔股票重用相同的表單輸入。
+func cloneValuesWithStock(values url.Values, stockNo string) url.Values {
+	cloned := make(url.Values, len(values)+1)
+	for k, v := range values {
+		cloned[k] = append([]string(nil), v...)
+	}
+	cloned.Set("stock_no", stockNo)
+	return cloned
+}
+
+// wantsJSON 判斷請求是否要求 JSON 輸出 (?format=json 或 Accept: application/json)。
+func wantsJSON(r *http.Request) bool {
+	if strings.EqualFold(r.URL.Query().Get("format"), "json") {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+// perTickerFetcher 依股票代號回傳不同結果，用來模擬比較模式下部分標的失敗的情境。
+type perTickerFetcher struct {
+	results map[string]revenue.FetchResult
+	errs    map[string]error
+}
+
+func (f *perTickerFetcher) Fetch(ctx context.Context, stockNo string) (revenue.FetchResult, error) {
+	if err, ok := f.errs[stockNo]; ok {
+		return revenue.FetchResult{}, err
+	}
+	return f.results[stockNo], nil
+}
+
+func TestHandleComparisonJSON(t *testing.T) {
+	records := make([]revenue.MonthlyRevenue, 0, 13)
+	base2023 := []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}
+	for i, v := range base2023 {
+		records = append(records, revenue.MonthlyRevenue{Year: 2023, Month: time.Month(i + 1), Revenue: v})
+	}
+	records = append(records, revenue.MonthlyRevenue{Year: 2024, Month: time.January, Revenue: 388})
+
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: records, Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{}},
+		nil,
+	)
+	app.now = func() time.Time { return time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC) }
+
+	req := httptest.NewRequest(http.MethodGet, "/?stock_no=2330,2454&format=json", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d, body: %s", rr.Code, rr.Body.String())
+	}
+
+	var comparison ComparisonView
+	if err := json.Unmarshal(rr.Body.Bytes(), &comparison); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(comparison.Tickers) != 2 {
+		t.Fatalf("expected 2 tickers, got %d", len(comparison.Tickers))
+	}
+	if comparison.Tickers[0].StockNo != "2330" || comparison.Tickers[1].StockNo != "2454" {
+		t.Fatalf("unexpected ticker order: %+v", comparison.Tickers)
+	}
+	for _, tp := range comparison.Tickers {
+		if tp.Error != "" {
+			t.Fatalf("unexpected per-ticker error: %s", tp.Error)
+		}
+	}
+}
+
+func TestHandleComparisonPartialFailure(t *testing.T) {
+	records := make([]revenue.MonthlyRevenue, 0, 13)
+	base2023 := []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}
+	for i, v := range base2023 {
+		records = append(records, revenue.MonthlyRevenue{Year: 2023, Month: time.Month(i + 1), Revenue: v})
+	}
+	records = append(records, revenue.MonthlyRevenue{Year: 2024, Month: time.January, Revenue: 388})
+
+	fetcher := &perTickerFetcher{
+		results: map[string]revenue.FetchResult{
+			"2330": {Records: records, Source: revenue.SourceFallback},
+		},
+		errs: map[string]error{
+			"2317": errors.New("upstream unavailable"),
+		},
+	}
+
+	app := NewApp(fetcher, &stubEarnings{result: financials.FetchResult{}}, nil)
+	app.now = func() time.Time { return time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC) }
+
+	req := httptest.NewRequest(http.MethodGet, "/?stock_no=2330,2317&format=json&yoy_02=10", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d, body: %s", rr.Code, rr.Body.String())
+	}
+
+	var comparison ComparisonView
+	if err := json.Unmarshal(rr.Body.Bytes(), &comparison); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(comparison.Tickers) != 2 {
+		t.Fatalf("expected 2 tickers, got %d", len(comparison.Tickers))
+	}
+
+	ok, failed := comparison.Tickers[0], comparison.Tickers[1]
+	if ok.Error != "" {
+		t.Fatalf("expected 2330 to succeed, got error: %s", ok.Error)
+	}
+	if ok.Projection == nil {
+		t.Fatalf("expected 2330 to have a projection")
+	}
+	if failed.Error == "" {
+		t.Fatalf("expected 2317 to report an error")
+	}
+	if failed.Projection != nil {
+		t.Fatalf("expected 2317 to have no projection")
+	}
+
+	if ok.Months[1].InputValue != "10.00" {
+		t.Fatalf("expected manual yoy override to apply uniformly across tickers: %+v", ok.Months[1])
+	}
+}
+
+func TestSplitStockNos(t *testing.T) {
+	got := splitStockNos(" 2330 , ,2454,2330 ")
+	want := []string{"2330", "2454", "2330"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
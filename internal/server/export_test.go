@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func TestHandleExportCSV(t *testing.T) {
+	records := make([]revenue.MonthlyRevenue, 0, 13)
+	base2023 := []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}
+	for i, v := range base2023 {
+		records = append(records, revenue.MonthlyRevenue{Year: 2023, Month: time.Month(i + 1), Revenue: v})
+	}
+	records = append(records, revenue.MonthlyRevenue{Year: 2024, Month: time.January, Revenue: 388})
+
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: records, Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{}},
+		nil,
+	)
+	app.now = func() time.Time { return time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC) }
+
+	req := httptest.NewRequest(http.MethodGet, "/export?stock_no=2330&format=csv&yoy_02=10", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d, body: %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Fatalf("unexpected content type: %s", ct)
+	}
+	if disp := rr.Header().Get("Content-Disposition"); !strings.Contains(disp, "2330_2024_projection.csv") {
+		t.Fatalf("unexpected content disposition: %s", disp)
+	}
+
+	reader := csv.NewReader(strings.NewReader(rr.Body.String()))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("failed to decode csv: %v", err)
+	}
+	if len(rows) == 0 || rows[0][0] != "Month" {
+		t.Fatalf("unexpected header row: %v", rows[0])
+	}
+	monthHeaderRow := rows[0]
+
+	var janRow, febRow []string
+	for _, row := range rows {
+		if len(row) != len(monthHeaderRow) {
+			continue
+		}
+		if row[0] == "1" {
+			janRow = row
+		}
+		if row[0] == "2" {
+			febRow = row
+		}
+	}
+	if janRow == nil || janRow[5] != "true" {
+		t.Fatalf("expected January to be marked actual: %v", janRow)
+	}
+	if febRow == nil || febRow[5] != "false" {
+		t.Fatalf("expected February to be estimated: %v", febRow)
+	}
+	if febRow[3] != "10.00" {
+		t.Fatalf("expected manual yoy override to flow through export: %v", febRow)
+	}
+}
+
+func TestHandleExportRequiresFetcher(t *testing.T) {
+	app := NewApp(nil, nil, nil)
+	app.now = func() time.Time { return time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC) }
+
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected bad request when fetcher missing, got %d", rr.Code)
+	}
+}
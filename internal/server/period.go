@@ -0,0 +1,287 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+	"github.com/yilab8/stock_auto_work/internal/valuation"
+)
+
+// PeriodMetric 描述單一指標在本期與對照期間的數值、差額與漲跌幅。
+type PeriodMetric struct {
+	Label         string
+	CurrentValue  float64
+	PreviousValue float64
+	DeltaAbsolute float64
+	DeltaPercent  float64
+}
+
+// PeriodComparisonView 彙整「本期 vs 對照期」的儀表板資料，供首頁以 KPI 卡片並排呈現。
+type PeriodComparisonView struct {
+	Mode          string
+	CurrentLabel  string
+	PreviousLabel string
+	Metrics       []PeriodMetric
+	Note          string
+}
+
+// newPeriodMetric 依本期與對照期數值組出單一指標的絕對與百分比差額。
+func newPeriodMetric(label string, current, previous float64) PeriodMetric {
+	delta := current - previous
+	deltaPercent := 0.0
+	if previous != 0 {
+		deltaPercent = delta / previous * 100
+	}
+	return PeriodMetric{
+		Label:         label,
+		CurrentValue:  current,
+		PreviousValue: previous,
+		DeltaAbsolute: delta,
+		DeltaPercent:  deltaPercent,
+	}
+}
+
+// parsePeriod 解析 period 參數："month"、"quarter"、"ytd"、"year" 或加上 "previous_" 前綴，
+// 代表再往前回推一期 (例如 previous_quarter 代表「上季 vs 上上季」)。
+func parsePeriod(raw string) (mode string, shiftBack bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if strings.HasPrefix(raw, "previous_") {
+		return strings.TrimPrefix(raw, "previous_"), true
+	}
+	return raw, false
+}
+
+// buildActualQuarters 篩選指定年度的已公告季度稅後淨利與 EPS，供 valuation.BuildYearProjection
+// 套用實際值覆寫推估結果。
+func buildActualQuarters(records []financials.QuarterlyReport, year int) map[int]valuation.QuarterActual {
+	actualQuarters := make(map[int]valuation.QuarterActual)
+	for _, record := range records {
+		if record.Year == year {
+			actualQuarters[record.Quarter] = valuation.QuarterActual{
+				NetIncome: record.NetIncome,
+				EPS:       record.BasicEPS,
+			}
+		}
+	}
+	return actualQuarters
+}
+
+// buildYearProjectionFor 重用首頁相同的分組資料與假設值，組出指定年度的 YearProjection，
+// 讓期間比較模式可以取得非目前選取年度 (例如去年、前年) 的推估結果。
+func buildYearProjectionFor(year int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, assumptions valuation.Assumptions, earningsRecords []financials.QuarterlyReport) (valuation.YearProjection, error) {
+	yearAssumptions := assumptions
+	yearAssumptions.ActualQuarters = buildActualQuarters(earningsRecords, year)
+	return valuation.BuildYearProjection(year, grouped, manualYoY, yearAssumptions, defaultPredictRules()...)
+}
+
+// buildPeriodComparison 依 period 參數組出本期 vs 對照期間的比較；month/quarter 僅在目前年度
+// 範圍內比較相鄰區間，ytd/year 則會視需要重新計算前一年度的 YearProjection。
+func buildPeriodComparison(mode string, shiftBack bool, activeYear int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, assumptions valuation.Assumptions, earningsRecords []financials.QuarterlyReport, currentProjection valuation.YearProjection) (*PeriodComparisonView, error) {
+	offset := 0
+	if shiftBack {
+		offset = 1
+	}
+	switch mode {
+	case "month":
+		return buildMonthPeriodComparison(currentProjection, offset), nil
+	case "quarter":
+		return buildQuarterPeriodComparison(currentProjection, offset), nil
+	case "ytd":
+		return buildYTDPeriodComparison(activeYear, offset, grouped, manualYoY, assumptions, earningsRecords, currentProjection)
+	case "year":
+		return buildYearPeriodComparison(activeYear, offset, grouped, manualYoY, assumptions, earningsRecords, currentProjection)
+	default:
+		return nil, fmt.Errorf("未知的 period 參數: %s", mode)
+	}
+}
+
+// buildMonthPeriodComparison 比較目前年度中最新的已公告月份與其前一個月，僅提供營收指標，
+// 因為月度資料未拆分毛利與稅後淨利。
+func buildMonthPeriodComparison(projection valuation.YearProjection, offset int) *PeriodComparisonView {
+	actual := make([]valuation.MonthEstimate, 0, len(projection.Months))
+	for _, m := range projection.Months {
+		if m.IsActual {
+			actual = append(actual, m)
+		}
+	}
+	idx := len(actual) - 1 - offset
+	if idx < 1 {
+		return &PeriodComparisonView{Mode: "month", Note: "目前年度已公告月份不足，無法比較相鄰月份"}
+	}
+	cur, prev := actual[idx], actual[idx-1]
+	curRevenue, _ := cur.Revenue.Float64()
+	prevRevenue, _ := prev.Revenue.Float64()
+	return &PeriodComparisonView{
+		Mode:          "month",
+		CurrentLabel:  fmt.Sprintf("%d年%02d月", cur.Year, int(cur.Month)),
+		PreviousLabel: fmt.Sprintf("%d年%02d月", prev.Year, int(prev.Month)),
+		Metrics:       []PeriodMetric{newPeriodMetric("營收", curRevenue, prevRevenue)},
+	}
+}
+
+// buildQuarterPeriodComparison 比較目前年度中最新的已公告季度與其前一季，兩者皆在同一年度內。
+func buildQuarterPeriodComparison(projection valuation.YearProjection, offset int) *PeriodComparisonView {
+	actual := make([]valuation.QuarterBreakdown, 0, len(projection.Quarters))
+	for _, q := range projection.Quarters {
+		if q.IsActual {
+			actual = append(actual, q)
+		}
+	}
+	idx := len(actual) - 1 - offset
+	if idx < 1 {
+		return &PeriodComparisonView{Mode: "quarter", Note: "目前年度已公告季度不足，無法比較相鄰季度"}
+	}
+	cur, prev := actual[idx], actual[idx-1]
+	curRevenue, _ := cur.Revenue.Float64()
+	prevRevenue, _ := prev.Revenue.Float64()
+	curGrossProfit, _ := cur.GrossProfit.Float64()
+	prevGrossProfit, _ := prev.GrossProfit.Float64()
+	curNetIncome, _ := cur.NetIncome.Float64()
+	prevNetIncome, _ := prev.NetIncome.Float64()
+	curEPS, _ := cur.EPS.Float64()
+	prevEPS, _ := prev.EPS.Float64()
+	return &PeriodComparisonView{
+		Mode:          "quarter",
+		CurrentLabel:  fmt.Sprintf("%d年第%d季", projection.Year, cur.Quarter),
+		PreviousLabel: fmt.Sprintf("%d年第%d季", projection.Year, prev.Quarter),
+		Metrics: []PeriodMetric{
+			newPeriodMetric("營收", curRevenue, prevRevenue),
+			newPeriodMetric("毛利", curGrossProfit, prevGrossProfit),
+			newPeriodMetric("稅後淨利", curNetIncome, prevNetIncome),
+			newPeriodMetric("EPS", curEPS, prevEPS),
+		},
+	}
+}
+
+// buildYearPeriodComparison 比較 activeYear-offset 與其前一年度的全年推估結果。
+func buildYearPeriodComparison(activeYear, offset int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, assumptions valuation.Assumptions, earningsRecords []financials.QuarterlyReport, currentProjection valuation.YearProjection) (*PeriodComparisonView, error) {
+	currentYear := activeYear - offset
+	current := currentProjection
+	if offset != 0 {
+		proj, err := buildYearProjectionFor(currentYear, grouped, manualYoY, assumptions, earningsRecords)
+		if err != nil {
+			return &PeriodComparisonView{Mode: "year", Note: fmt.Sprintf("無法取得 %d 年資料: %v", currentYear, err)}, nil
+		}
+		current = proj
+	}
+	previous, err := buildYearProjectionFor(currentYear-1, grouped, manualYoY, assumptions, earningsRecords)
+	if err != nil {
+		return &PeriodComparisonView{Mode: "year", Note: fmt.Sprintf("無法取得 %d 年資料: %v", currentYear-1, err)}, nil
+	}
+	curRevenue, _ := current.AnnualRevenue.Float64()
+	prevRevenue, _ := previous.AnnualRevenue.Float64()
+	curEPS, _ := current.AnnualEPS.Float64()
+	prevEPS, _ := previous.AnnualEPS.Float64()
+	return &PeriodComparisonView{
+		Mode:          "year",
+		CurrentLabel:  fmt.Sprintf("%d 年", currentYear),
+		PreviousLabel: fmt.Sprintf("%d 年", currentYear-1),
+		Metrics: []PeriodMetric{
+			newPeriodMetric("營收", curRevenue, prevRevenue),
+			newPeriodMetric("毛利", sumGrossProfit(current.Quarters), sumGrossProfit(previous.Quarters)),
+			newPeriodMetric("稅後淨利", sumNetIncome(current.Quarters), sumNetIncome(previous.Quarters)),
+			newPeriodMetric("EPS", curEPS, prevEPS),
+		},
+	}, nil
+}
+
+// buildYTDPeriodComparison 比較 activeYear-offset 截至最新已公告季度的累計數字，與前一年度同樣
+// 季度數的累計數字 (例如今年累計前兩季 vs 去年累計前兩季)。
+func buildYTDPeriodComparison(activeYear, offset int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, assumptions valuation.Assumptions, earningsRecords []financials.QuarterlyReport, currentProjection valuation.YearProjection) (*PeriodComparisonView, error) {
+	currentYear := activeYear - offset
+	current := currentProjection
+	if offset != 0 {
+		proj, err := buildYearProjectionFor(currentYear, grouped, manualYoY, assumptions, earningsRecords)
+		if err != nil {
+			return &PeriodComparisonView{Mode: "ytd", Note: fmt.Sprintf("無法取得 %d 年資料: %v", currentYear, err)}, nil
+		}
+		current = proj
+	}
+	quartersElapsed := countActualQuarters(current.Quarters)
+	if quartersElapsed == 0 {
+		return &PeriodComparisonView{Mode: "ytd", Note: fmt.Sprintf("%d 年尚無已公告季度可供累計比較", currentYear)}, nil
+	}
+	previous, err := buildYearProjectionFor(currentYear-1, grouped, manualYoY, assumptions, earningsRecords)
+	if err != nil {
+		return &PeriodComparisonView{Mode: "ytd", Note: fmt.Sprintf("無法取得 %d 年資料: %v", currentYear-1, err)}, nil
+	}
+	return &PeriodComparisonView{
+		Mode:          "ytd",
+		CurrentLabel:  fmt.Sprintf("%d 年累計前 %d 季", currentYear, quartersElapsed),
+		PreviousLabel: fmt.Sprintf("%d 年累計前 %d 季", currentYear-1, quartersElapsed),
+		Metrics: []PeriodMetric{
+			newPeriodMetric("營收", sumRevenue(current.Quarters, quartersElapsed), sumRevenue(previous.Quarters, quartersElapsed)),
+			newPeriodMetric("毛利", sumGrossProfitUpTo(current.Quarters, quartersElapsed), sumGrossProfitUpTo(previous.Quarters, quartersElapsed)),
+			newPeriodMetric("稅後淨利", sumNetIncomeUpTo(current.Quarters, quartersElapsed), sumNetIncomeUpTo(previous.Quarters, quartersElapsed)),
+			newPeriodMetric("EPS", sumEPSUpTo(current.Quarters, quartersElapsed), sumEPSUpTo(previous.Quarters, quartersElapsed)),
+		},
+	}, nil
+}
+
+func countActualQuarters(quarters []valuation.QuarterBreakdown) int {
+	count := 0
+	for _, q := range quarters {
+		if q.IsActual {
+			count++
+		}
+	}
+	return count
+}
+
+// sumRevenue 與以下各 sum* 輔助函式皆為渲染邊界：加總 valuation.QuarterBreakdown 的
+// decimal.Decimal 欄位後才轉換為 float64，避免先轉型再相加而重新引入浮點誤差。
+func sumRevenue(quarters []valuation.QuarterBreakdown, upTo int) float64 {
+	total := decimal.Zero
+	for _, q := range quarters {
+		if q.Quarter <= upTo {
+			total = total.Add(q.Revenue)
+		}
+	}
+	v, _ := total.Float64()
+	return v
+}
+
+func sumGrossProfit(quarters []valuation.QuarterBreakdown) float64 {
+	return sumGrossProfitUpTo(quarters, len(quarters))
+}
+
+func sumGrossProfitUpTo(quarters []valuation.QuarterBreakdown, upTo int) float64 {
+	total := decimal.Zero
+	for _, q := range quarters {
+		if q.Quarter <= upTo {
+			total = total.Add(q.GrossProfit)
+		}
+	}
+	v, _ := total.Float64()
+	return v
+}
+
+func sumNetIncome(quarters []valuation.QuarterBreakdown) float64 {
+	return sumNetIncomeUpTo(quarters, len(quarters))
+}
+
+func sumNetIncomeUpTo(quarters []valuation.QuarterBreakdown, upTo int) float64 {
+	total := decimal.Zero
+	for _, q := range quarters {
+		if q.Quarter <= upTo {
+			total = total.Add(q.NetIncome)
+		}
+	}
+	v, _ := total.Float64()
+	return v
+}
+
+func sumEPSUpTo(quarters []valuation.QuarterBreakdown, upTo int) float64 {
+	total := decimal.Zero
+	for _, q := range quarters {
+		if q.Quarter <= upTo {
+			total = total.Add(q.EPS)
+		}
+	}
+	v, _ := total.Float64()
+	return v
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func periodTestTemplate(t *testing.T) *template.Template {
+	t.Helper()
+	return template.Must(template.New("test").Parse(`{{if .Period}}{{.Period.Mode}}|{{.Period.Note}}|{{len .Period.Metrics}}{{end}}`))
+}
+
+func twoYearRecords() []revenue.MonthlyRevenue {
+	records := make([]revenue.MonthlyRevenue, 0, 18)
+	base2023 := []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}
+	for i, v := range base2023 {
+		records = append(records, revenue.MonthlyRevenue{Year: 2023, Month: time.Month(i + 1), Revenue: v})
+	}
+	base2024 := []float64{330, 310, 350}
+	for i, v := range base2024 {
+		records = append(records, revenue.MonthlyRevenue{Year: 2024, Month: time.Month(i + 1), Revenue: v})
+	}
+	return records
+}
+
+func TestHandleHomePeriodQuarterComparison(t *testing.T) {
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: twoYearRecords(), Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{}},
+		periodTestTemplate(t),
+	)
+	app.now = func() time.Time { return time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC) }
+
+	req := httptest.NewRequest(http.MethodGet, "/?stock_no=2330&year=2024&period=quarter", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d, body: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestBuildQuarterPeriodComparisonInsufficientData(t *testing.T) {
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: twoYearRecords(), Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{}},
+		periodTestTemplate(t),
+	)
+	app.now = func() time.Time { return time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC) }
+
+	req := httptest.NewRequest(http.MethodGet, "/?stock_no=2330&year=2024&period=previous_quarter", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d, body: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	mode, shiftBack := parsePeriod("quarter")
+	if mode != "quarter" || shiftBack {
+		t.Fatalf("unexpected parse result: %s %v", mode, shiftBack)
+	}
+	mode, shiftBack = parsePeriod("previous_year")
+	if mode != "year" || !shiftBack {
+		t.Fatalf("unexpected parse result: %s %v", mode, shiftBack)
+	}
+}
+
+func TestBuildYearPeriodComparison(t *testing.T) {
+	grouped := revenue.GroupByYear(twoYearRecords())
+	assumptions := formValues{
+		GrossMargin:        "50",
+		OperatingExpense:   "10",
+		NonOperatingIncome: "0",
+		TaxRate:            "20",
+		Shares:             "100",
+		PerMultiple:        "15",
+	}.toAssumptions()
+
+	currentProjection, err := buildYearProjectionFor(2024, grouped, nil, assumptions, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building current projection: %v", err)
+	}
+
+	view, err := buildYearPeriodComparison(2024, 0, grouped, nil, assumptions, nil, currentProjection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if view.Mode != "year" {
+		t.Fatalf("unexpected mode: %s", view.Mode)
+	}
+	if len(view.Metrics) != 4 {
+		t.Fatalf("expected 4 metrics, got %d", len(view.Metrics))
+	}
+	if view.Metrics[0].CurrentValue <= 0 {
+		t.Fatalf("expected positive current revenue, got %+v", view.Metrics[0])
+	}
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yilab8/stock_auto_work/internal/valuation"
+)
+
+// sensitivityAxisOptions 為 /sensitivity 表單下拉選單提供可供掃描的欄位清單，順序與
+// 請求中列出的順序一致。
+var sensitivityAxisOptions = []valuation.SensitivityAxis{
+	valuation.AxisGrossMargin,
+	valuation.AxisOperatingExpense,
+	valuation.AxisNonOperatingIncome,
+	valuation.AxisTaxRate,
+	valuation.AxisPerMultiple,
+	valuation.AxisPrevQuarterEPS,
+}
+
+// defaultSensitivitySteps 為未指定 row_steps/col_steps 時的預設格數。
+const defaultSensitivitySteps = 5
+
+// handleSensitivity 依 stock_no 與估值假設重建 Assumptions，沿使用者指定 (或預設) 的
+// 兩個軸掃描出 EstimatedPrice / Upside 矩陣，供前端以熱力圖表格呈現目標價對關鍵假設的敏感度。
+func (a *App) handleSensitivity(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	stockNo, year, grouped, manualYoY, assumptions, err := a.buildAssumptionContext(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rowRange, colRange, err := parseSensitivityRanges(query, assumptions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	matrix, err := valuation.BuildSensitivityMatrix(year, grouped, manualYoY, assumptions, rowRange, colRange, defaultPredictRules()...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	view := toSensitivityView(matrix)
+
+	if wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if err := json.NewEncoder(w).Encode(view); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if a.Template == nil {
+		http.Error(w, "template not configured", http.StatusInternalServerError)
+		return
+	}
+	data := &pageData{
+		StockNo:      stockNo,
+		Year:         year,
+		StockOptions: buildStockOptions(a.companyProvider().List(), ""),
+		Sensitivity:  &view,
+	}
+	a.render(w, data)
+}
+
+// parseSensitivityRanges 依 query 中的 row_axis/col_axis/row_delta/col_delta/row_steps/col_steps
+// 組出兩個 SensitivityRange；未指定時 row_axis 預設為 GrossMargin、col_axis 預設為 PerMultiple。
+func parseSensitivityRanges(query url.Values, assumptions valuation.Assumptions) (valuation.SensitivityRange, valuation.SensitivityRange, error) {
+	rowAxis := valuation.SensitivityAxis(pickValue(query, "row_axis", "", string(valuation.AxisGrossMargin)))
+	colAxis := valuation.SensitivityAxis(pickValue(query, "col_axis", "", string(valuation.AxisPerMultiple)))
+	if !valuation.ValidSensitivityAxis(rowAxis) {
+		return valuation.SensitivityRange{}, valuation.SensitivityRange{}, fmt.Errorf("不支援的 row_axis: %s", rowAxis)
+	}
+	if !valuation.ValidSensitivityAxis(colAxis) {
+		return valuation.SensitivityRange{}, valuation.SensitivityRange{}, fmt.Errorf("不支援的 col_axis: %s", colAxis)
+	}
+
+	rowDelta := parseSensitivityFloat(query.Get("row_delta"), defaultSensitivityDelta(rowAxis, assumptions))
+	colDelta := parseSensitivityFloat(query.Get("col_delta"), defaultSensitivityDelta(colAxis, assumptions))
+	rowSteps, err := parseSensitivityInt("row_steps", query.Get("row_steps"), defaultSensitivitySteps)
+	if err != nil {
+		return valuation.SensitivityRange{}, valuation.SensitivityRange{}, err
+	}
+	colSteps, err := parseSensitivityInt("col_steps", query.Get("col_steps"), defaultSensitivitySteps)
+	if err != nil {
+		return valuation.SensitivityRange{}, valuation.SensitivityRange{}, err
+	}
+
+	return valuation.SensitivityRange{Axis: rowAxis, Delta: rowDelta, Steps: rowSteps},
+		valuation.SensitivityRange{Axis: colAxis, Delta: colDelta, Steps: colSteps},
+		nil
+}
+
+// defaultSensitivityDelta 依軸的量級給出預設掃描幅度：比率型欄位 (GrossMargin、TaxRate)
+// 預設 ±5 個百分點，PerMultiple 預設 ±3 倍，其餘金額型欄位預設為基準值的 ±20%。
+func defaultSensitivityDelta(axis valuation.SensitivityAxis, assumptions valuation.Assumptions) float64 {
+	switch axis {
+	case valuation.AxisGrossMargin, valuation.AxisTaxRate:
+		return 0.05
+	case valuation.AxisPerMultiple:
+		return 3
+	case valuation.AxisOperatingExpense:
+		return assumptions.OperatingExpense * 0.2
+	case valuation.AxisNonOperatingIncome:
+		return assumptions.NonOperatingIncome * 0.2
+	case valuation.AxisPrevQuarterEPS:
+		return assumptions.PrevQuarterEPS * 0.2
+	default:
+		return 0
+	}
+}
+
+func parseSensitivityFloat(raw string, fallback float64) float64 {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return fallback
+	}
+	if v, err := strconv.ParseFloat(raw, 64); err == nil {
+		return v
+	}
+	return fallback
+}
+
+// parseSensitivityInt 解析 row_steps/col_steps 等格數參數；空值回傳 fallback，格式錯誤或
+// 非正值比照空值處理，但超出 valuation.MaxSensitivitySteps 上限時回傳錯誤而非靜默截斷，
+// 避免呼叫端以為實際只掃描了上限格數。
+func parseSensitivityInt(name, raw string, fallback int) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v <= 0 {
+		return fallback, nil
+	}
+	if v > valuation.MaxSensitivitySteps {
+		return 0, fmt.Errorf("%s 超出上限 %d: %d", name, valuation.MaxSensitivitySteps, v)
+	}
+	return v, nil
+}
+
+// sensitivityCellView 為模板呈現熱力圖表格的單一格點資料。
+type sensitivityCellView struct {
+	RowValue       float64
+	ColValue       float64
+	EstimatedPrice float64
+	Upside         float64
+	UpsidePercent  float64
+	Err            string
+}
+
+// SensitivityView 為 /sensitivity 回應的呈現層資料，Rows 依 matrix.RowValues 排列，
+// 每一列的 Cells 則依 matrix.ColValues 排列，對應熱力圖表格的列與欄。
+type SensitivityView struct {
+	RowAxis     string
+	ColAxis     string
+	RowValues   []float64
+	ColValues   []float64
+	Rows        [][]sensitivityCellView
+	AxisChoices []string
+}
+
+func toSensitivityView(matrix valuation.SensitivityMatrix) SensitivityView {
+	rows := make([][]sensitivityCellView, len(matrix.Cells))
+	for i, row := range matrix.Cells {
+		cells := make([]sensitivityCellView, len(row))
+		for j, cell := range row {
+			cells[j] = sensitivityCellView{
+				RowValue:       cell.RowValue,
+				ColValue:       cell.ColValue,
+				EstimatedPrice: cell.EstimatedPrice.InexactFloat64(),
+				Upside:         cell.Upside.InexactFloat64(),
+				UpsidePercent:  cell.Upside.InexactFloat64() * 100,
+				Err:            cell.Err,
+			}
+		}
+		rows[i] = cells
+	}
+
+	choices := make([]string, 0, len(sensitivityAxisOptions))
+	for _, axis := range sensitivityAxisOptions {
+		choices = append(choices, string(axis))
+	}
+
+	return SensitivityView{
+		RowAxis:     string(matrix.RowAxis),
+		ColAxis:     string(matrix.ColAxis),
+		RowValues:   matrix.RowValues,
+		ColValues:   matrix.ColValues,
+		Rows:        rows,
+		AxisChoices: choices,
+	}
+}
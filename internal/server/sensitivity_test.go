@@ -0,0 +1,68 @@
+package server
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func sensitivityTestTemplate(t *testing.T) *template.Template {
+	t.Helper()
+	return template.Must(template.New("test").Parse(`{{if .Sensitivity}}{{len .Sensitivity.Rows}}{{end}}`))
+}
+
+func sensitivityTestRecords() []revenue.MonthlyRevenue {
+	records := make([]revenue.MonthlyRevenue, 0, 12)
+	for i, v := range []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410} {
+		records = append(records, revenue.MonthlyRevenue{Year: 2023, Month: time.Month(i + 1), Revenue: v})
+	}
+	return records
+}
+
+func TestHandleSensitivityRejectsExcessiveSteps(t *testing.T) {
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: sensitivityTestRecords(), Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{}},
+		sensitivityTestTemplate(t),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/sensitivity?stock_no=2330&year=2023&row_steps=100000", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestParseSensitivityIntWithinLimit(t *testing.T) {
+	v, err := parseSensitivityInt("row_steps", "10", defaultSensitivitySteps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 10 {
+		t.Fatalf("expected 10, got %d", v)
+	}
+}
+
+func TestParseSensitivityIntRejectsExceedingLimit(t *testing.T) {
+	_, err := parseSensitivityInt("row_steps", "51", defaultSensitivitySteps)
+	if err == nil {
+		t.Fatalf("expected an error for a value above the max")
+	}
+}
+
+func TestParseSensitivityIntFallsBackOnInvalidInput(t *testing.T) {
+	v, err := parseSensitivityInt("row_steps", "not-a-number", defaultSensitivitySteps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != defaultSensitivitySteps {
+		t.Fatalf("expected fallback %d, got %d", defaultSensitivitySteps, v)
+	}
+}
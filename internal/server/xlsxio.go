@@ -0,0 +1,134 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/report"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+// rawExportFilename 為 monthly_xlsx/quarterly_xlsx 原始資料匯出所用的檔名，與
+// exportFilename (推估結果匯出) 區分，避免誤以為是推估結果。
+func rawExportFilename(stockNo, kind, ext string) string {
+	return fmt.Sprintf("%s_%s.%s", stockNo, kind, ext)
+}
+
+// handleMonthlyRevenueExport 匯出 stock_no 完整月營收原始資料 (Summary/Monthly/YoY 分頁)，
+// 供使用者於 Excel 中人工校正後經 /import 重新匯入。
+func (a *App) handleMonthlyRevenueExport(w http.ResponseWriter, r *http.Request, query url.Values) {
+	stockNo := strings.TrimSpace(query.Get("stock_no"))
+	if stockNo == "" {
+		stockNo = "2330"
+	}
+	if a.Fetcher == nil {
+		http.Error(w, "尚未設定資料來源", http.StatusBadRequest)
+		return
+	}
+	result, err := a.Fetcher.Fetch(r.Context(), stockNo)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("取得營收資料失敗: %v", err), http.StatusBadRequest)
+		return
+	}
+	company := result.Company
+	if company == nil {
+		company, _ = a.companyProvider().Lookup(stockNo)
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", rawExportFilename(stockNo, "monthly_revenue", "xlsx")))
+	if err := report.WriteMonthlyRevenueXLSX(w, company, result.Records); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleQuarterlyReportExport 匯出 stock_no 完整季度損益原始資料 (Summary/Quarterly 分頁)，
+// 供使用者於 Excel 中人工校正後經 /import 重新匯入。
+func (a *App) handleQuarterlyReportExport(w http.ResponseWriter, r *http.Request, query url.Values) {
+	stockNo := strings.TrimSpace(query.Get("stock_no"))
+	if stockNo == "" {
+		stockNo = "2330"
+	}
+	if a.Earnings == nil {
+		http.Error(w, "尚未設定檢表資料來源", http.StatusBadRequest)
+		return
+	}
+	result, err := a.Earnings.Fetch(r.Context(), stockNo)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("取得檢表資料失敗: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", rawExportFilename(stockNo, "quarterly_report", "xlsx")))
+	if err := report.WriteQuarterlyReportsXLSX(w, stockNo, result.Records); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleImport 接收 /export?format=monthly_xlsx 或 quarterly_xlsx 匯出後經人工校正的活頁簿，
+// 依 ?type=monthly|quarterly 選擇對應的 Read*XLSX 還原函式，並以 Normalize() 驗證每一列的
+// 格式，回傳正規化後的結構化資料供使用者確認校正結果。App 本身沒有持久化層，匯入結果僅止於
+// 單次請求內驗證與回顯，不會覆寫任何快取或資料來源。
+func (a *App) handleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "僅接受 POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("讀取上傳檔案失敗: %v", err), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	switch strings.ToLower(r.URL.Query().Get("type")) {
+	case "quarterly":
+		raw, err := report.ReadQuarterlyReportsXLSX(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reports := make([]financials.QuarterlyReport, 0, len(raw))
+		for _, rec := range raw {
+			value, err := rec.Normalize()
+			if err != nil {
+				http.Error(w, fmt.Sprintf("第 %d 列格式錯誤: %v", len(reports)+2, err), http.StatusBadRequest)
+				return
+			}
+			reports = append(reports, value)
+		}
+		writeJSON(w, financials.SortQuarterlyReports(reports))
+	case "monthly":
+		raw, err := report.ReadMonthlyRevenueXLSX(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		records := make([]revenue.MonthlyRevenue, 0, len(raw))
+		for _, rec := range raw {
+			value, err := rec.Normalize()
+			if err != nil {
+				http.Error(w, fmt.Sprintf("第 %d 列格式錯誤: %v", len(records)+2, err), http.StatusBadRequest)
+				return
+			}
+			records = append(records, value)
+		}
+		writeJSON(w, revenue.SortMonthlyRevenues(records))
+	default:
+		http.Error(w, "請指定 ?type=monthly 或 ?type=quarterly", http.StatusBadRequest)
+	}
+}
+
+// writeJSON 以 JSON 格式輸出匯入結果，供使用者或前端腳本確認校正後的資料。
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/yilab8/stock_auto_work/internal/financials"
+	"github.com/yilab8/stock_auto_work/internal/report"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func xlsxioTestTemplate(t *testing.T) *template.Template {
+	t.Helper()
+	return template.Must(template.New("test").Parse(`ok`))
+}
+
+func TestHandleExportMonthlyXLSXWritesMonthlySheet(t *testing.T) {
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: sensitivityTestRecords(), Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{}},
+		xlsxioTestTemplate(t),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/export?stock_no=2330&format=monthly_xlsx", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+	f, err := excelize.OpenReader(bytes.NewReader(rr.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("expected a valid xlsx file: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Monthly")
+	if err != nil {
+		t.Fatalf("expected a Monthly sheet: %v", err)
+	}
+	if len(rows) != len(sensitivityTestRecords())+1 {
+		t.Fatalf("expected %d rows including header, got %d", len(sensitivityTestRecords())+1, len(rows))
+	}
+}
+
+func TestHandleExportQuarterlyXLSXWritesQuarterlySheet(t *testing.T) {
+	reports := []financials.QuarterlyReport{
+		{CompanyCode: "2330", Year: 2023, Quarter: 4, NetIncome: 2000, BasicEPS: 8.5},
+	}
+	app := NewApp(
+		&stubFetcher{result: revenue.FetchResult{Records: sensitivityTestRecords(), Source: revenue.SourceFallback}},
+		&stubEarnings{result: financials.FetchResult{Records: reports}},
+		xlsxioTestTemplate(t),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/export?stock_no=2330&format=quarterly_xlsx", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+	f, err := excelize.OpenReader(bytes.NewReader(rr.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("expected a valid xlsx file: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Quarterly")
+	if err != nil {
+		t.Fatalf("expected a Quarterly sheet: %v", err)
+	}
+	if len(rows) != len(reports)+1 {
+		t.Fatalf("expected %d rows including header, got %d", len(reports)+1, len(rows))
+	}
+}
+
+// newImportRequest 將 xlsx 內容包成 multipart/form-data 請求，模擬使用者上傳校正後的活頁簿。
+func newImportRequest(t *testing.T, importType string, xlsx []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "upload.xlsx")
+	if err != nil {
+		t.Fatalf("unexpected error creating form file: %v", err)
+	}
+	if _, err := part.Write(xlsx); err != nil {
+		t.Fatalf("unexpected error writing form file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error closing writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/import?type="+importType, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestHandleImportRoundTripsMonthlyRevenue(t *testing.T) {
+	var buf bytes.Buffer
+	records := []revenue.MonthlyRevenue{{Year: 2023, Month: 1, Revenue: 100}}
+	if err := report.WriteMonthlyRevenueXLSX(&buf, &revenue.StaticCompany{StockNo: "2330"}, records); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	app := NewApp(&stubFetcher{}, &stubEarnings{}, xlsxioTestTemplate(t))
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, newImportRequest(t, "monthly", buf.Bytes()))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+	var got []revenue.MonthlyRevenue
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("expected valid JSON: %v", err)
+	}
+	if len(got) != 1 || got[0].Revenue != 100 {
+		t.Fatalf("unexpected normalized records: %+v", got)
+	}
+}
+
+func TestHandleImportRoundTripsQuarterlyReports(t *testing.T) {
+	var buf bytes.Buffer
+	reports := []financials.QuarterlyReport{{CompanyCode: "2330", Year: 2023, Quarter: 4, NetIncome: 2000, BasicEPS: 8.5}}
+	if err := report.WriteQuarterlyReportsXLSX(&buf, "2330", reports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	app := NewApp(&stubFetcher{}, &stubEarnings{}, xlsxioTestTemplate(t))
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, newImportRequest(t, "quarterly", buf.Bytes()))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+	var got []financials.QuarterlyReport
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("expected valid JSON: %v", err)
+	}
+	if len(got) != 1 || got[0].BasicEPS != 8.5 {
+		t.Fatalf("unexpected normalized records: %+v", got)
+	}
+}
+
+func TestHandleImportRejectsMissingType(t *testing.T) {
+	app := NewApp(&stubFetcher{}, &stubEarnings{}, xlsxioTestTemplate(t))
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, newImportRequest(t, "", []byte("not-a-real-file")))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestHandleImportRejectsGet(t *testing.T) {
+	app := NewApp(&stubFetcher{}, &stubEarnings{}, xlsxioTestTemplate(t))
+	req := httptest.NewRequest(http.MethodGet, "/import?type=monthly", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d, body: %s", rr.Code, rr.Body.String())
+	}
+}
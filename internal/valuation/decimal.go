@@ -0,0 +1,65 @@
+package valuation
+
+import "github.com/shopspring/decimal"
+
+// DefaultCurrencyPlaces 與 DefaultRatioPlaces 為未指定 Rounding 時採用的預設精度：
+// 貨幣金額 (營收、毛利、稅後淨利、EPS) 保留 2 位小數，比率 (YoY、MoM、Upside) 保留 4 位小數。
+const (
+	DefaultCurrencyPlaces int32 = 2
+	DefaultRatioPlaces    int32 = 4
+)
+
+// DefaultPredictClamp 為未指定 Assumptions.PredictClamp 時使用的 YoY 上下限 (±200%)，
+// 避免 predict 規則在資料雜訊下推算出失真過大的複利成長率。
+const DefaultPredictClamp = 2.0
+
+// clampRatio 將 d 限制在 ±limit 之間；limit 小於等於 0 時表示不設限。
+func clampRatio(d decimal.Decimal, limit float64) decimal.Decimal {
+	if limit <= 0 {
+		return d
+	}
+	limitDec := decimal.NewFromFloat(limit)
+	if d.GreaterThan(limitDec) {
+		return limitDec
+	}
+	if neg := limitDec.Neg(); d.LessThan(neg) {
+		return neg
+	}
+	return d
+}
+
+// RoundingPolicy 定義 BuildYearProjection 計算過程中金額與比率各自捨入的小數位數，
+// 採用 HALF_EVEN (banker's rounding) 以避免大量加總時偏向單一方向累積誤差。
+type RoundingPolicy struct {
+	CurrencyPlaces int32
+	RatioPlaces    int32
+}
+
+func (p RoundingPolicy) currencyPlaces() int32 {
+	if p.CurrencyPlaces > 0 {
+		return p.CurrencyPlaces
+	}
+	return DefaultCurrencyPlaces
+}
+
+func (p RoundingPolicy) ratioPlaces() int32 {
+	if p.RatioPlaces > 0 {
+		return p.RatioPlaces
+	}
+	return DefaultRatioPlaces
+}
+
+// roundCurrency 以 HALF_EVEN 將金額捨入至本 policy 指定的貨幣位數。
+func (p RoundingPolicy) roundCurrency(d decimal.Decimal) decimal.Decimal {
+	return d.RoundBank(p.currencyPlaces())
+}
+
+// roundRatio 以 HALF_EVEN 將比率捨入至本 policy 指定的位數。
+func (p RoundingPolicy) roundRatio(d decimal.Decimal) decimal.Decimal {
+	return d.RoundBank(p.ratioPlaces())
+}
+
+func init() {
+	// 提高除法精度以減少季度/年度加總前的中間誤差，最終輸出仍依 RoundingPolicy 捨入。
+	decimal.DivisionPrecision = 16
+}
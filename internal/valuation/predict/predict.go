@@ -0,0 +1,109 @@
+// Package predict 提供以規則為基礎 (rule-based) 的月度 YoY 推算引擎，取代單純套用
+// 全年平均 YoY 的作法。BuildYearProjection 會依序嘗試呼叫端提供的 Rule，第一個
+// 能產生結果的規則即被採用，並記錄於 MonthEstimate.RuleUsed 供畫面呈現。
+package predict
+
+import (
+	"sort"
+	"time"
+
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+// Context 提供規則計算單一月份 YoY 推估值時所需的歷史營收資料。
+type Context struct {
+	Grouped map[int][]revenue.MonthlyRevenue
+	Year    int
+	Month   time.Month
+	// Clamp 為 YoY 上下限 (例如 2.0 代表 ±200%)，小於等於 0 時表示不設限。
+	Clamp float64
+}
+
+// Rule 依 Context 推算指定月份的 YoY；資料不足以支撐該規則時回傳 ok=false，
+// 由呼叫端改嘗試下一條規則。
+type Rule interface {
+	Name() string
+	Predict(ctx Context) (yoy float64, ok bool)
+}
+
+// monthRevenue 在 grouped[year] 中尋找指定月份的營收。
+func monthRevenue(grouped map[int][]revenue.MonthlyRevenue, year int, month time.Month) (float64, bool) {
+	for _, rec := range grouped[year] {
+		if rec.Month == month {
+			return rec.Revenue, true
+		}
+	}
+	return 0, false
+}
+
+// clampYoY 將 yoy 限制在 ±limit 之間，避免極端值在逐月推估時複利放大。
+func clampYoY(yoy, limit float64) float64 {
+	if limit <= 0 {
+		return yoy
+	}
+	if yoy > limit {
+		return limit
+	}
+	if yoy < -limit {
+		return -limit
+	}
+	return yoy
+}
+
+// point 為最小平方法迴歸使用的 (index, value) 資料點，index 依觀察順序由 0 遞增。
+type point struct {
+	index float64
+	value float64
+}
+
+// leastSquares 以最小平方法對 points 擬合 value = intercept + slope*index。
+// 資料點少於 2 筆時回傳 ok=false。
+func leastSquares(points []point) (slope, intercept float64, ok bool) {
+	n := float64(len(points))
+	if n < 2 {
+		return 0, 0, false
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		sumX += p.index
+		sumY += p.value
+		sumXY += p.index * p.value
+		sumXX += p.index * p.index
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, 0, false
+	}
+	slope = (n*sumXY - sumX*sumY) / denominator
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept, true
+}
+
+// history 收集 (year, month) 之前所有已觀察到的月份營收，依時間先後排序 (舊到新)。
+func history(grouped map[int][]revenue.MonthlyRevenue, year int, month time.Month) []point {
+	type observed struct {
+		year    int
+		month   time.Month
+		revenue float64
+	}
+	var all []observed
+	for y, records := range grouped {
+		for _, rec := range records {
+			if y > year || (y == year && rec.Month >= month) {
+				continue
+			}
+			all = append(all, observed{year: y, month: rec.Month, revenue: rec.Revenue})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].year == all[j].year {
+			return all[i].month < all[j].month
+		}
+		return all[i].year < all[j].year
+	})
+	points := make([]point, len(all))
+	for i, o := range all {
+		points[i] = point{index: float64(i), value: o.revenue}
+	}
+	return points
+}
@@ -0,0 +1,209 @@
+package predict
+
+import "fmt"
+
+// MovingAverageRule 取同一曆月在過去 N 年的 YoY 平均值。
+type MovingAverageRule struct {
+	N int
+}
+
+// MovingAverage 建立取過去 N 年同月 YoY 平均值的規則；N 小於等於 0 時預設為 3。
+func MovingAverage(n int) MovingAverageRule {
+	if n <= 0 {
+		n = 3
+	}
+	return MovingAverageRule{N: n}
+}
+
+func (r MovingAverageRule) Name() string {
+	return fmt.Sprintf("moving_average_%d", r.N)
+}
+
+// Predict 平均過去 N 年中，同一曆月「當年 vs 前一年」的 YoY。
+func (r MovingAverageRule) Predict(ctx Context) (float64, bool) {
+	sum := 0.0
+	count := 0
+	for y := ctx.Year - 1; y >= ctx.Year-r.N; y-- {
+		cur, curOK := monthRevenue(ctx.Grouped, y, ctx.Month)
+		prev, prevOK := monthRevenue(ctx.Grouped, y-1, ctx.Month)
+		if !curOK || !prevOK || prev <= 0 {
+			continue
+		}
+		sum += (cur - prev) / prev
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return clampYoY(sum/float64(count), ctx.Clamp), true
+}
+
+// SameMonthLastYearRule 直接沿用去年同月的 YoY。
+type SameMonthLastYearRule struct{}
+
+// SameMonthLastYear 建立直接沿用去年同月 YoY 的規則。
+func SameMonthLastYear() SameMonthLastYearRule {
+	return SameMonthLastYearRule{}
+}
+
+func (SameMonthLastYearRule) Name() string {
+	return "same_month_last_year"
+}
+
+func (SameMonthLastYearRule) Predict(ctx Context) (float64, bool) {
+	cur, curOK := monthRevenue(ctx.Grouped, ctx.Year-1, ctx.Month)
+	prev, prevOK := monthRevenue(ctx.Grouped, ctx.Year-2, ctx.Month)
+	if !curOK || !prevOK || prev <= 0 {
+		return 0, false
+	}
+	return clampYoY((cur-prev)/prev, ctx.Clamp), true
+}
+
+// LinearTrendRule 以最近 K 個已觀察月份的營收做最小平方法迴歸，外推下一個月的營收，
+// 再與去年同月營收相比換算成 YoY。
+type LinearTrendRule struct {
+	K int
+}
+
+// LinearTrend 建立以最近 K 個月做線性迴歸外推的規則；K 小於等於 0 時預設為 6。
+func LinearTrend(k int) LinearTrendRule {
+	if k <= 0 {
+		k = 6
+	}
+	return LinearTrendRule{K: k}
+}
+
+func (r LinearTrendRule) Name() string {
+	return fmt.Sprintf("linear_trend_%d", r.K)
+}
+
+func (r LinearTrendRule) Predict(ctx Context) (float64, bool) {
+	points := history(ctx.Grouped, ctx.Year, ctx.Month)
+	if len(points) > r.K {
+		points = points[len(points)-r.K:]
+	}
+	// 重新編號 index，讓迴歸的外推點固定為 len(points) (即下一筆觀察值)。
+	for i := range points {
+		points[i].index = float64(i)
+	}
+	slope, intercept, ok := leastSquares(points)
+	if !ok {
+		return 0, false
+	}
+	predicted := intercept + slope*float64(len(points))
+	prevYearRevenue, ok := monthRevenue(ctx.Grouped, ctx.Year-1, ctx.Month)
+	if !ok || prevYearRevenue <= 0 {
+		return 0, false
+	}
+	return clampYoY((predicted-prevYearRevenue)/prevYearRevenue, ctx.Clamp), true
+}
+
+// SeasonalIndexRule 將每月營收拆解為「年度趨勢 × 季節因子」：季節因子為過去 Years 年
+// 該曆月營收佔當年平均月營收的比例平均值，再以年度平均營收的線性趨勢外推下一年度，
+// 兩者相乘得出推估營收，最後換算為 YoY。
+type SeasonalIndexRule struct {
+	Years int
+}
+
+// SeasonalIndex 建立以過去 Years 年資料做季節性分解的規則；Years 小於等於 0 時預設為 3。
+func SeasonalIndex(years int) SeasonalIndexRule {
+	if years <= 0 {
+		years = 3
+	}
+	return SeasonalIndexRule{Years: years}
+}
+
+func (r SeasonalIndexRule) Name() string {
+	return fmt.Sprintf("seasonal_index_%d", r.Years)
+}
+
+func (r SeasonalIndexRule) Predict(ctx Context) (float64, bool) {
+	type yearStat struct {
+		avg       float64
+		factor    float64
+		hasFactor bool
+	}
+	stats := make([]yearStat, 0, r.Years)
+	for y := ctx.Year - r.Years; y <= ctx.Year-1; y++ {
+		records := ctx.Grouped[y]
+		if len(records) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, rec := range records {
+			sum += rec.Revenue
+		}
+		avg := sum / float64(len(records))
+		if avg <= 0 {
+			continue
+		}
+		stat := yearStat{avg: avg}
+		if monthRev, ok := monthRevenue(ctx.Grouped, y, ctx.Month); ok {
+			stat.factor = monthRev / avg
+			stat.hasFactor = true
+		}
+		stats = append(stats, stat)
+	}
+	if len(stats) < 2 {
+		return 0, false
+	}
+
+	factorSum, factorCount := 0.0, 0
+	for _, s := range stats {
+		if s.hasFactor {
+			factorSum += s.factor
+			factorCount++
+		}
+	}
+	if factorCount == 0 {
+		return 0, false
+	}
+	seasonalFactor := factorSum / float64(factorCount)
+
+	points := make([]point, len(stats))
+	for i, s := range stats {
+		points[i] = point{index: float64(i), value: s.avg}
+	}
+	var projectedAvg float64
+	if slope, intercept, ok := leastSquares(points); ok {
+		projectedAvg = intercept + slope*float64(len(points))
+	} else {
+		sum := 0.0
+		for _, s := range stats {
+			sum += s.avg
+		}
+		projectedAvg = sum / float64(len(stats))
+	}
+	if projectedAvg <= 0 {
+		return 0, false
+	}
+
+	predicted := projectedAvg * seasonalFactor
+	prevYearRevenue, ok := monthRevenue(ctx.Grouped, ctx.Year-1, ctx.Month)
+	if !ok || prevYearRevenue <= 0 {
+		return 0, false
+	}
+	return clampYoY((predicted-prevYearRevenue)/prevYearRevenue, ctx.Clamp), true
+}
+
+// ManualOverrideRule 將使用者手動輸入的 YoY 包裝為規則，方便與其他規則排在同一優先序清單中。
+type ManualOverrideRule struct {
+	Values map[int]float64
+}
+
+// ManualOverride 建立手動覆寫規則；key 為曆月 (1-12)。
+func ManualOverride(values map[int]float64) ManualOverrideRule {
+	return ManualOverrideRule{Values: values}
+}
+
+func (ManualOverrideRule) Name() string {
+	return "manual_override"
+}
+
+func (r ManualOverrideRule) Predict(ctx Context) (float64, bool) {
+	v, ok := r.Values[int(ctx.Month)]
+	if !ok {
+		return 0, false
+	}
+	return clampYoY(v, ctx.Clamp), true
+}
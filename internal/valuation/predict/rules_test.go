@@ -0,0 +1,135 @@
+package predict
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func buildYear(year int, values []float64) []revenue.MonthlyRevenue {
+	out := make([]revenue.MonthlyRevenue, len(values))
+	for i, v := range values {
+		out[i] = revenue.MonthlyRevenue{Year: year, Month: time.Month(i + 1), Revenue: v}
+	}
+	return out
+}
+
+func TestSameMonthLastYear(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2022: buildYear(2022, []float64{100, 100, 100}),
+		2023: buildYear(2023, []float64{110, 110, 110}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.January}
+	yoy, ok := SameMonthLastYear().Predict(ctx)
+	if !ok {
+		t.Fatalf("expected a prediction")
+	}
+	if math.Abs(yoy-0.1) > 1e-9 {
+		t.Fatalf("unexpected yoy: %f", yoy)
+	}
+}
+
+func TestSameMonthLastYearMissingHistory(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildYear(2023, []float64{110}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.January}
+	if _, ok := SameMonthLastYear().Predict(ctx); ok {
+		t.Fatalf("expected no prediction without two prior years of data")
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2021: buildYear(2021, []float64{100}),
+		2022: buildYear(2022, []float64{110}), // yoy 10%
+		2023: buildYear(2023, []float64{121}), // yoy 10%
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.January}
+	yoy, ok := MovingAverage(2).Predict(ctx)
+	if !ok {
+		t.Fatalf("expected a prediction")
+	}
+	if math.Abs(yoy-0.1) > 1e-6 {
+		t.Fatalf("unexpected yoy: %f", yoy)
+	}
+}
+
+func TestLinearTrendInsufficientHistory(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2024: buildYear(2024, []float64{100}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.February}
+	if _, ok := LinearTrend(6).Predict(ctx); ok {
+		t.Fatalf("expected no prediction with a single observed month")
+	}
+}
+
+func TestLinearTrendProjectsForward(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildYear(2023, []float64{90, 95, 100, 105, 110}),
+		2024: buildYear(2024, []float64{100, 105, 110, 115}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.May}
+	yoy, ok := LinearTrend(4).Predict(ctx)
+	if !ok {
+		t.Fatalf("expected a prediction")
+	}
+	if yoy <= 0 {
+		t.Fatalf("expected positive yoy from an upward trend, got %f", yoy)
+	}
+}
+
+func TestSeasonalIndexRequiresTwoYears(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildYear(2023, []float64{100, 200, 100}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.February}
+	if _, ok := SeasonalIndex(3).Predict(ctx); ok {
+		t.Fatalf("expected no prediction with only one prior year")
+	}
+}
+
+func TestSeasonalIndex(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2022: buildYear(2022, []float64{100, 200, 100}),
+		2023: buildYear(2023, []float64{110, 220, 110}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.February}
+	yoy, ok := SeasonalIndex(3).Predict(ctx)
+	if !ok {
+		t.Fatalf("expected a prediction")
+	}
+	if yoy <= 0 {
+		t.Fatalf("expected positive yoy given rising seasonal revenue, got %f", yoy)
+	}
+}
+
+func TestManualOverride(t *testing.T) {
+	rule := ManualOverride(map[int]float64{3: 0.25})
+	ctx := Context{Year: 2024, Month: time.March}
+	yoy, ok := rule.Predict(ctx)
+	if !ok || math.Abs(yoy-0.25) > 1e-9 {
+		t.Fatalf("unexpected result: %f, %v", yoy, ok)
+	}
+	if _, ok := rule.Predict(Context{Year: 2024, Month: time.April}); ok {
+		t.Fatalf("expected no override for unspecified month")
+	}
+}
+
+func TestClampYoY(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2022: buildYear(2022, []float64{10}),
+		2023: buildYear(2023, []float64{1000}),
+	}
+	ctx := Context{Grouped: grouped, Year: 2024, Month: time.January, Clamp: 2.0}
+	yoy, ok := SameMonthLastYear().Predict(ctx)
+	if !ok {
+		t.Fatalf("expected a prediction")
+	}
+	if yoy != 2.0 {
+		t.Fatalf("expected yoy clamped to 2.0, got %f", yoy)
+	}
+}
@@ -5,46 +5,53 @@ import (
 	"sort"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/yilab8/stock_auto_work/internal/revenue"
+	"github.com/yilab8/stock_auto_work/internal/valuation/predict"
 )
 
-// MonthEstimate 描述單月營收與年增率狀態。
+// MonthEstimate 描述單月營收與年增率狀態。金額與比率皆以 decimal.Decimal 計算，
+// 避免逐月加總至季度、年度時累積浮點誤差。
 type MonthEstimate struct {
 	Year                 int
 	Month                time.Month
-	Revenue              float64
-	PreviousRevenue      float64
-	YoY                  float64 // 例如 0.15 表示 15%
-	PreviousMonthRevenue float64
-	MoM                  float64
-	ReferenceYoY         float64
-	ReferenceMoM         float64
-	ReferenceRevenue     float64
+	Revenue              decimal.Decimal
+	PreviousRevenue      decimal.Decimal
+	YoY                  decimal.Decimal // 例如 0.15 表示 15%
+	PreviousMonthRevenue decimal.Decimal
+	MoM                  decimal.Decimal
+	ReferenceYoY         decimal.Decimal
+	ReferenceMoM         decimal.Decimal
+	ReferenceRevenue     decimal.Decimal
 	HasReference         bool
 	IsActual             bool
+	// RuleUsed 記錄推估月份所採用的 predict.Rule 名稱 ("manual_override"、"average" 或
+	// 各 Rule.Name() 的回傳值)；已公告的實際月份則維持空字串。
+	RuleUsed string
 }
 
-// QuarterInputs 為單季計算 EPS 所需的基本假設。
+// QuarterInputs 為單季計算 EPS 所需的基本假設，由 Assumptions 的 float64 輸入轉換而來。
 type QuarterInputs struct {
-	GrossMargin        float64
-	OperatingExpense   float64
-	NonOperatingIncome float64
-	TaxRate            float64
+	GrossMargin        decimal.Decimal
+	OperatingExpense   decimal.Decimal
+	NonOperatingIncome decimal.Decimal
+	TaxRate            decimal.Decimal
 }
 
 // QuarterBreakdown 保留單季重要推估數據。
 type QuarterBreakdown struct {
 	Quarter         int
-	Revenue         float64
-	GrossProfit     float64
-	OperatingIncome float64
-	PreTaxIncome    float64
-	NetIncome       float64
-	EPS             float64
+	Revenue         decimal.Decimal
+	GrossProfit     decimal.Decimal
+	OperatingIncome decimal.Decimal
+	PreTaxIncome    decimal.Decimal
+	NetIncome       decimal.Decimal
+	EPS             decimal.Decimal
 	IsActual        bool
 }
 
-// Assumptions 為全年估值使用的主要輸入值。
+// Assumptions 為全年估值使用的主要輸入值。輸入欄位維持 float64 (表單/外部資料邊界)，
+// BuildYearProjection 內部會依 Rounding 轉換為 decimal.Decimal 進行計算。
 type Assumptions struct {
 	GrossMargin        float64
 	OperatingExpense   float64
@@ -54,8 +61,18 @@ type Assumptions struct {
 	PrevQuarterEPS     float64
 	PerMultiple        float64
 	CurrentPrice       float64
-	QuarterOverrides   map[int]QuarterOverride
-	ActualQuarters     map[int]QuarterActual
+	// GrowthRate 為葛拉漢估值法使用的預期年成長率，格式與 AvgYoY 相同 (0.15 表示 15%)。
+	// 為 0 時會改用該年度的 AvgYoY。
+	GrowthRate float64
+	// BondYield 為目前 AAA 級公司債殖利率 (百分比數值，例如 4.4 表示 4.4%)。
+	// 為 0 時會退回葛拉漢原始公式使用的 4.4。
+	BondYield        float64
+	QuarterOverrides map[int]QuarterOverride
+	ActualQuarters   map[int]QuarterActual
+	// Rounding 決定貨幣與比率欄位的捨入位數與方式，零值會套用 DefaultCurrencyPlaces/DefaultRatioPlaces。
+	Rounding RoundingPolicy
+	// PredictClamp 限制 predict 規則與平均值後備方案推算出的 YoY 上下限，零值套用 DefaultPredictClamp。
+	PredictClamp float64
 }
 
 // QuarterOverride 提供個別季度的覆寫參數。
@@ -77,16 +94,27 @@ type YearProjection struct {
 	Year           int
 	Months         []MonthEstimate
 	Quarters       []QuarterBreakdown
-	AnnualRevenue  float64
-	AnnualEPS      float64
-	EstimatedPrice float64
-	Upside         float64
-	AvgYoY         float64
-	AvgMoM         float64
+	AnnualRevenue  decimal.Decimal
+	AnnualEPS      decimal.Decimal
+	EstimatedPrice decimal.Decimal
+	Upside         decimal.Decimal
+	AvgYoY         decimal.Decimal
+	AvgMoM         decimal.Decimal
+	// IntrinsicValue 為葛拉漢修正公式計算出的內在價值，與 PE 推估的 EstimatedPrice 互為對照。
+	IntrinsicValue decimal.Decimal
+	// MarginOfSafety 為 (IntrinsicValue - CurrentPrice) / IntrinsicValue，數值越高代表安全邊際越大。
+	MarginOfSafety decimal.Decimal
 }
 
-// BuildYearProjection 組合單一年份的營收推估與估值計算。
-func BuildYearProjection(year int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, asm Assumptions) (YearProjection, error) {
+// BuildYearProjection 組合單一年份的營收推估與估值計算。rules 依序嘗試用來推算未公告
+// 月份的 YoY，第一個回傳 ok=true 的規則即被採用並記錄於 MonthEstimate.RuleUsed；
+// manualYoY 中的手動覆寫一律優先於 rules。
+func BuildYearProjection(year int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, asm Assumptions, rules ...predict.Rule) (YearProjection, error) {
+	rounding := asm.Rounding
+	predictClamp := asm.PredictClamp
+	if predictClamp == 0 {
+		predictClamp = DefaultPredictClamp
+	}
 	current := revenue.SortMonthlyRevenues(grouped[year])
 	previous := revenue.SortMonthlyRevenues(grouped[year-1])
 	if len(current) == 0 {
@@ -95,6 +123,7 @@ func BuildYearProjection(year int, grouped map[int][]revenue.MonthlyRevenue, man
 	if asm.SharesOutstanding <= 0 {
 		return YearProjection{}, fmt.Errorf("SharesOutstanding 必須大於 0")
 	}
+	shares := decimal.NewFromFloat(asm.SharesOutstanding)
 
 	monthMap := make(map[time.Month]revenue.MonthlyRevenue)
 	for _, rec := range current {
@@ -103,25 +132,26 @@ func BuildYearProjection(year int, grouped map[int][]revenue.MonthlyRevenue, man
 		}
 		monthMap[rec.Month] = rec
 	}
-	prevMap := make(map[time.Month]float64)
+	prevMap := make(map[time.Month]decimal.Decimal)
 	for _, rec := range previous {
-		prevMap[rec.Month] = rec.Revenue
+		prevMap[rec.Month] = decimal.NewFromFloat(rec.Revenue)
 	}
 
-	yoySum := 0.0
+	yoySum := decimal.Zero
 	yoyCount := 0
-	actualYoY := make(map[time.Month]float64)
-	momSum := 0.0
+	actualYoY := make(map[time.Month]decimal.Decimal)
+	momSum := decimal.Zero
 	momCount := 0
-	actualMoM := make(map[time.Month]float64)
+	actualMoM := make(map[time.Month]decimal.Decimal)
 	for month, rec := range monthMap {
-		prev := prevMap[month]
-		if prev <= 0 {
+		prev, ok := prevMap[month]
+		if !ok || prev.LessThanOrEqual(decimal.Zero) {
 			continue
 		}
-		yoy := (rec.Revenue - prev) / prev
+		recRevenue := decimal.NewFromFloat(rec.Revenue)
+		yoy := recRevenue.Sub(prev).Div(prev)
 		actualYoY[month] = yoy
-		yoySum += yoy
+		yoySum = yoySum.Add(yoy)
 		yoyCount++
 	}
 	// 依月份排序，方便取得前一月資料
@@ -133,116 +163,131 @@ func BuildYearProjection(year int, grouped map[int][]revenue.MonthlyRevenue, man
 	for _, month := range sortedMonths {
 		rec := monthMap[month]
 		prevMonth := previousMonth(month)
-		var prevRevenue float64
+		prevRevenue := decimal.Zero
 		if prevRec, ok := monthMap[prevMonth]; ok && prevRec.Year == rec.Year {
-			prevRevenue = prevRec.Revenue
+			prevRevenue = decimal.NewFromFloat(prevRec.Revenue)
 		} else if prevMonth == time.December {
 			if prev, ok := prevMap[prevMonth]; ok {
 				prevRevenue = prev
 			}
 		}
-		if prevRevenue <= 0 {
+		if prevRevenue.LessThanOrEqual(decimal.Zero) {
 			continue
 		}
-		mom := (rec.Revenue - prevRevenue) / prevRevenue
+		recRevenue := decimal.NewFromFloat(rec.Revenue)
+		mom := recRevenue.Sub(prevRevenue).Div(prevRevenue)
 		actualMoM[month] = mom
-		momSum += mom
+		momSum = momSum.Add(mom)
 		momCount++
 	}
-	avgYoY := 0.0
+	avgYoY := decimal.Zero
 	if yoyCount > 0 {
-		avgYoY = yoySum / float64(yoyCount)
+		avgYoY = rounding.roundRatio(yoySum.Div(decimal.NewFromInt(int64(yoyCount))))
 	}
-	avgMoM := 0.0
+	avgMoM := decimal.Zero
 	if momCount > 0 {
-		avgMoM = momSum / float64(momCount)
+		avgMoM = rounding.roundRatio(momSum.Div(decimal.NewFromInt(int64(momCount))))
 	}
 
 	months := make([]MonthEstimate, 0, 12)
-	totalRevenue := 0.0
-	var lastRevenue float64
+	totalRevenue := decimal.Zero
+	lastRevenue := decimal.Zero
 	for m := time.January; m <= time.December; m++ {
 		prevYearRevenue := prevMap[m]
 		prevMonthRevenue := lastRevenue
 		if rec, ok := monthMap[m]; ok {
+			recRevenue := decimal.NewFromFloat(rec.Revenue)
 			yoy := actualYoY[m]
-			if prevMonthRevenue <= 0 {
+			if prevMonthRevenue.LessThanOrEqual(decimal.Zero) {
 				prevMonthRevenue = previousYearMonthRevenue(monthMap, prevMap, rec)
 			}
 			mom, hasActualMoM := actualMoM[m]
 			if !hasActualMoM {
-				if prevMonthRevenue > 0 {
-					mom = (rec.Revenue - prevMonthRevenue) / prevMonthRevenue
+				if prevMonthRevenue.GreaterThan(decimal.Zero) {
+					mom = recRevenue.Sub(prevMonthRevenue).Div(prevMonthRevenue)
 				}
 			}
 			months = append(months, MonthEstimate{
 				Year:                 year,
 				Month:                m,
-				Revenue:              rec.Revenue,
-				PreviousRevenue:      prevYearRevenue,
-				PreviousMonthRevenue: prevMonthRevenue,
-				YoY:                  yoy,
-				MoM:                  mom,
+				Revenue:              rounding.roundCurrency(recRevenue),
+				PreviousRevenue:      rounding.roundCurrency(prevYearRevenue),
+				PreviousMonthRevenue: rounding.roundCurrency(prevMonthRevenue),
+				YoY:                  rounding.roundRatio(yoy),
+				MoM:                  rounding.roundRatio(mom),
 				IsActual:             true,
 			})
-			totalRevenue += rec.Revenue
-			lastRevenue = rec.Revenue
+			totalRevenue = totalRevenue.Add(recRevenue)
+			lastRevenue = recRevenue
 			continue
 		}
 		yoy := avgYoY
+		yoy = clampRatio(yoy, predictClamp)
+		ruleUsed := "average"
 		if v, ok := manualYoY[m]; ok {
-			yoy = v
+			yoy = clampRatio(decimal.NewFromFloat(v), predictClamp)
+			ruleUsed = "manual_override"
+		} else {
+			ctx := predict.Context{Grouped: grouped, Year: year, Month: m, Clamp: predictClamp}
+			for _, rule := range rules {
+				if v, ok := rule.Predict(ctx); ok {
+					yoy = decimal.NewFromFloat(v)
+					ruleUsed = rule.Name()
+					break
+				}
+			}
 		}
 		referenceYoY := yoy
 		referenceMoM := avgMoM
-		revenueYoY := prevYearRevenue * (1 + referenceYoY)
-		revenueMoM := prevMonthRevenue * (1 + referenceMoM)
-		revenue := revenueYoY
+		revenueYoY := prevYearRevenue.Mul(decimal.NewFromInt(1).Add(referenceYoY))
+		revenueMoM := prevMonthRevenue.Mul(decimal.NewFromInt(1).Add(referenceMoM))
+		estimatedRevenue := revenueYoY
 		hasReference := false
-		if prevYearRevenue > 0 {
+		if prevYearRevenue.GreaterThan(decimal.Zero) {
 			hasReference = true
-			revenue = revenueYoY
-		} else if prevMonthRevenue > 0 {
+			estimatedRevenue = revenueYoY
+		} else if prevMonthRevenue.GreaterThan(decimal.Zero) {
 			hasReference = true
-			revenue = revenueMoM
+			estimatedRevenue = revenueMoM
 		}
-		var mom float64
-		if prevMonthRevenue > 0 {
-			mom = (revenue - prevMonthRevenue) / prevMonthRevenue
+		mom := decimal.Zero
+		if prevMonthRevenue.GreaterThan(decimal.Zero) {
+			mom = estimatedRevenue.Sub(prevMonthRevenue).Div(prevMonthRevenue)
 		}
 		months = append(months, MonthEstimate{
 			Year:                 year,
 			Month:                m,
-			Revenue:              revenue,
-			PreviousRevenue:      prevYearRevenue,
-			PreviousMonthRevenue: prevMonthRevenue,
-			YoY:                  yoy,
-			MoM:                  mom,
-			ReferenceYoY:         referenceYoY,
-			ReferenceMoM:         referenceMoM,
-			ReferenceRevenue:     chooseReferenceRevenue(prevYearRevenue, prevMonthRevenue, revenueYoY, revenueMoM),
+			Revenue:              rounding.roundCurrency(estimatedRevenue),
+			PreviousRevenue:      rounding.roundCurrency(prevYearRevenue),
+			PreviousMonthRevenue: rounding.roundCurrency(prevMonthRevenue),
+			YoY:                  rounding.roundRatio(yoy),
+			MoM:                  rounding.roundRatio(mom),
+			ReferenceYoY:         rounding.roundRatio(referenceYoY),
+			ReferenceMoM:         rounding.roundRatio(referenceMoM),
+			ReferenceRevenue:     rounding.roundCurrency(chooseReferenceRevenue(prevYearRevenue, prevMonthRevenue, revenueYoY, revenueMoM)),
 			HasReference:         hasReference,
 			IsActual:             false,
+			RuleUsed:             ruleUsed,
 		})
-		totalRevenue += revenue
-		if revenue > 0 {
-			lastRevenue = revenue
+		totalRevenue = totalRevenue.Add(estimatedRevenue)
+		if estimatedRevenue.GreaterThan(decimal.Zero) {
+			lastRevenue = estimatedRevenue
 		}
 	}
 	sort.Slice(months, func(i, j int) bool { return months[i].Month < months[j].Month })
 
 	quarters := make([]QuarterBreakdown, 0, 4)
-	annualEPS := 0.0
+	annualEPS := decimal.Zero
 	for q := 1; q <= 4; q++ {
 		start := (q-1)*3 + 1
 		end := start + 2
-		revenueSum := 0.0
+		revenueSum := decimal.Zero
 		allActual := true
 		for m := start; m <= end; m++ {
 			month := time.Month(m)
 			for _, item := range months {
 				if item.Month == month {
-					revenueSum += item.Revenue
+					revenueSum = revenueSum.Add(item.Revenue)
 					if !item.IsActual {
 						allActual = false
 					}
@@ -251,55 +296,85 @@ func BuildYearProjection(year int, grouped map[int][]revenue.MonthlyRevenue, man
 			}
 		}
 		inputs := asm.quarterInputs(q)
-		gross := revenueSum * inputs.GrossMargin
-		operating := gross - inputs.OperatingExpense
-		preTax := operating + inputs.NonOperatingIncome
-		netIncome := preTax * (1 - inputs.TaxRate)
-		eps := netIncome / asm.SharesOutstanding
+		gross := revenueSum.Mul(inputs.GrossMargin)
+		operating := gross.Sub(inputs.OperatingExpense)
+		preTax := operating.Add(inputs.NonOperatingIncome)
+		netIncome := preTax.Mul(decimal.NewFromInt(1).Sub(inputs.TaxRate))
+		eps := netIncome.Div(shares)
 		quarter := QuarterBreakdown{
 			Quarter:         q,
-			Revenue:         revenueSum,
-			GrossProfit:     gross,
-			OperatingIncome: operating,
-			PreTaxIncome:    preTax,
-			NetIncome:       netIncome,
-			EPS:             eps,
+			Revenue:         rounding.roundCurrency(revenueSum),
+			GrossProfit:     rounding.roundCurrency(gross),
+			OperatingIncome: rounding.roundCurrency(operating),
+			PreTaxIncome:    rounding.roundCurrency(preTax),
+			NetIncome:       rounding.roundCurrency(netIncome),
+			EPS:             rounding.roundCurrency(eps),
 			IsActual:        allActual,
 		}
 		if asm.ActualQuarters != nil {
 			if actual, ok := asm.ActualQuarters[q]; ok && actual.NetIncome > 0 {
-				quarter.NetIncome = actual.NetIncome
+				quarter.NetIncome = rounding.roundCurrency(decimal.NewFromFloat(actual.NetIncome))
 				if actual.EPS > 0 {
-					quarter.EPS = actual.EPS
+					quarter.EPS = rounding.roundCurrency(decimal.NewFromFloat(actual.EPS))
 				} else {
-					quarter.EPS = quarter.NetIncome / asm.SharesOutstanding
+					quarter.EPS = rounding.roundCurrency(quarter.NetIncome.Div(shares))
 				}
 				quarter.IsActual = true
 			}
 		}
 		quarters = append(quarters, quarter)
-		annualEPS += quarter.EPS
+		annualEPS = annualEPS.Add(quarter.EPS)
 	}
+	annualEPS = rounding.roundCurrency(annualEPS)
 
-	estimatedPrice := annualEPS * asm.PerMultiple
-	upside := 0.0
-	if asm.CurrentPrice > 0 {
-		upside = (estimatedPrice - asm.CurrentPrice) / asm.CurrentPrice
+	estimatedPrice := rounding.roundCurrency(annualEPS.Mul(decimal.NewFromFloat(asm.PerMultiple)))
+	currentPrice := decimal.NewFromFloat(asm.CurrentPrice)
+	upside := decimal.Zero
+	if currentPrice.GreaterThan(decimal.Zero) {
+		upside = rounding.roundRatio(estimatedPrice.Sub(currentPrice).Div(currentPrice))
 	}
 
+	intrinsicValue, marginOfSafety := grahamIntrinsicValue(annualEPS, avgYoY, asm, rounding)
+
 	return YearProjection{
 		Year:           year,
 		Months:         months,
 		Quarters:       quarters,
-		AnnualRevenue:  totalRevenue,
+		AnnualRevenue:  rounding.roundCurrency(totalRevenue),
 		AnnualEPS:      annualEPS,
 		EstimatedPrice: estimatedPrice,
 		Upside:         upside,
 		AvgYoY:         avgYoY,
 		AvgMoM:         avgMoM,
+		IntrinsicValue: intrinsicValue,
+		MarginOfSafety: marginOfSafety,
 	}, nil
 }
 
+// grahamIntrinsicValue 依葛拉漢修正公式計算內在價值與安全邊際：
+// IntrinsicValue = EPS * (8.5 + 2g) * 4.4 / Y，其中 g 為年成長率 (%)，Y 為 AAA 公司債殖利率 (%)。
+func grahamIntrinsicValue(annualEPS, avgYoY decimal.Decimal, asm Assumptions, rounding RoundingPolicy) (intrinsicValue, marginOfSafety decimal.Decimal) {
+	growth := decimal.NewFromFloat(asm.GrowthRate)
+	if growth.IsZero() {
+		growth = avgYoY
+	}
+	if growth.LessThan(decimal.Zero) {
+		growth = decimal.Zero
+	}
+	bondYield := decimal.NewFromFloat(asm.BondYield)
+	if bondYield.IsZero() {
+		bondYield = decimal.NewFromFloat(4.4)
+	}
+	multiplier := decimal.NewFromFloat(8.5).Add(decimal.NewFromInt(2).Mul(growth).Mul(decimal.NewFromInt(100)))
+	intrinsicValue = rounding.roundCurrency(annualEPS.Mul(multiplier).Mul(decimal.NewFromFloat(4.4)).Div(bondYield))
+	currentPrice := decimal.NewFromFloat(asm.CurrentPrice)
+	marginOfSafety = decimal.Zero
+	if intrinsicValue.GreaterThan(decimal.Zero) {
+		marginOfSafety = rounding.roundRatio(intrinsicValue.Sub(currentPrice).Div(intrinsicValue))
+	}
+	return intrinsicValue, marginOfSafety
+}
+
 func previousMonth(m time.Month) time.Month {
 	if m == time.January {
 		return time.December
@@ -307,54 +382,54 @@ func previousMonth(m time.Month) time.Month {
 	return m - 1
 }
 
-func previousYearMonthRevenue(current map[time.Month]revenue.MonthlyRevenue, prev map[time.Month]float64, rec revenue.MonthlyRevenue) float64 {
+func previousYearMonthRevenue(current map[time.Month]revenue.MonthlyRevenue, prev map[time.Month]decimal.Decimal, rec revenue.MonthlyRevenue) decimal.Decimal {
 	prevMonth := previousMonth(rec.Month)
 	if prevRec, ok := current[prevMonth]; ok && prevRec.Year == rec.Year {
-		return prevRec.Revenue
+		return decimal.NewFromFloat(prevRec.Revenue)
 	}
 	if prevMonth == time.December {
 		if v, ok := prev[prevMonth]; ok {
 			return v
 		}
 	}
-	return 0
+	return decimal.Zero
 }
 
-func chooseReferenceRevenue(prevYear, prevMonth, revenueYoY, revenueMoM float64) float64 {
-	if prevYear > 0 && prevMonth > 0 {
-		return (revenueYoY + revenueMoM) / 2
+func chooseReferenceRevenue(prevYear, prevMonth, revenueYoY, revenueMoM decimal.Decimal) decimal.Decimal {
+	if prevYear.GreaterThan(decimal.Zero) && prevMonth.GreaterThan(decimal.Zero) {
+		return revenueYoY.Add(revenueMoM).Div(decimal.NewFromInt(2))
 	}
-	if prevYear > 0 {
+	if prevYear.GreaterThan(decimal.Zero) {
 		return revenueYoY
 	}
-	if prevMonth > 0 {
+	if prevMonth.GreaterThan(decimal.Zero) {
 		return revenueMoM
 	}
-	return 0
+	return decimal.Zero
 }
 
 func (a Assumptions) quarterInputs(q int) QuarterInputs {
 	inputs := QuarterInputs{
-		GrossMargin:        a.GrossMargin,
-		OperatingExpense:   a.OperatingExpense,
-		NonOperatingIncome: a.NonOperatingIncome,
-		TaxRate:            a.TaxRate,
+		GrossMargin:        decimal.NewFromFloat(a.GrossMargin),
+		OperatingExpense:   decimal.NewFromFloat(a.OperatingExpense),
+		NonOperatingIncome: decimal.NewFromFloat(a.NonOperatingIncome),
+		TaxRate:            decimal.NewFromFloat(a.TaxRate),
 	}
 	if a.QuarterOverrides == nil {
 		return inputs
 	}
 	if override, ok := a.QuarterOverrides[q]; ok {
 		if override.GrossMargin != nil {
-			inputs.GrossMargin = *override.GrossMargin
+			inputs.GrossMargin = decimal.NewFromFloat(*override.GrossMargin)
 		}
 		if override.OperatingExpense != nil {
-			inputs.OperatingExpense = *override.OperatingExpense
+			inputs.OperatingExpense = decimal.NewFromFloat(*override.OperatingExpense)
 		}
 		if override.NonOperatingIncome != nil {
-			inputs.NonOperatingIncome = *override.NonOperatingIncome
+			inputs.NonOperatingIncome = decimal.NewFromFloat(*override.NonOperatingIncome)
 		}
 		if override.TaxRate != nil {
-			inputs.TaxRate = *override.TaxRate
+			inputs.TaxRate = decimal.NewFromFloat(*override.TaxRate)
 		}
 	}
 	return inputs
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/yilab8/stock_auto_work/internal/revenue"
 )
 
@@ -43,21 +44,21 @@ func TestBuildYearProjection(t *testing.T) {
 		t.Fatalf("January should be actual")
 	}
 	expectedJanYoY := (388.0 - 300.0) / 300.0
-	if math.Abs(jan.YoY-expectedJanYoY) > 1e-6 {
-		t.Fatalf("unexpected Jan YoY: %f", jan.YoY)
+	if math.Abs(jan.YoY.InexactFloat64()-expectedJanYoY) > 1e-4 {
+		t.Fatalf("unexpected Jan YoY: %v", jan.YoY)
 	}
-	if jan.PreviousMonthRevenue != 410 {
-		t.Fatalf("unexpected Jan previous month revenue: %f", jan.PreviousMonthRevenue)
+	if jan.PreviousMonthRevenue.InexactFloat64() != 410 {
+		t.Fatalf("unexpected Jan previous month revenue: %v", jan.PreviousMonthRevenue)
 	}
 
 	mar := projection.Months[2]
 	if mar.IsActual {
 		t.Fatalf("March should be estimated")
 	}
-	if math.Abs(mar.Revenue-352.0) > 1e-6 {
-		t.Fatalf("unexpected March revenue: %f", mar.Revenue)
+	if math.Abs(mar.Revenue.InexactFloat64()-352.0) > 1e-4 {
+		t.Fatalf("unexpected March revenue: %v", mar.Revenue)
 	}
-	if !mar.HasReference || mar.ReferenceRevenue <= 0 {
+	if !mar.HasReference || mar.ReferenceRevenue.LessThanOrEqual(decimal.Zero) {
 		t.Fatalf("expected reference data for March")
 	}
 
@@ -68,22 +69,21 @@ func TestBuildYearProjection(t *testing.T) {
 	if !q1.IsActual {
 		t.Fatalf("expected Q1 marked as actual")
 	}
-	if math.Abs(q1.NetIncome-1000.0) > 1e-6 {
-		t.Fatalf("unexpected Q1 net income override: %f", q1.NetIncome)
+	if math.Abs(q1.NetIncome.InexactFloat64()-1000.0) > 1e-4 {
+		t.Fatalf("unexpected Q1 net income override: %v", q1.NetIncome)
 	}
-	if math.Abs(q1.EPS-1.25) > 1e-6 {
-		t.Fatalf("unexpected Q1 EPS override: %f", q1.EPS)
-
+	if math.Abs(q1.EPS.InexactFloat64()-1.25) > 1e-4 {
+		t.Fatalf("unexpected Q1 EPS override: %v", q1.EPS)
 	}
-	if projection.EstimatedPrice <= 0 {
+	if projection.EstimatedPrice.LessThanOrEqual(decimal.Zero) {
 		t.Fatalf("expected positive estimated price")
 	}
-	if projection.AvgYoY <= 0 {
+	if projection.AvgYoY.LessThanOrEqual(decimal.Zero) {
 		t.Fatalf("expected positive avg YoY")
 	}
-	if projection.AvgMoM >= 0 {
+	if !projection.AvgMoM.LessThan(decimal.Zero) {
 		t.Fatalf("expected negative avg MoM due to下降")
-
+	}
 }
 
 func TestBuildYearProjectionSharesError(t *testing.T) {
@@ -98,6 +98,121 @@ func TestBuildYearProjectionSharesError(t *testing.T) {
 	}
 }
 
+func TestGrahamIntrinsicValueDefaults(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildMonthlyRevenue(2023, []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}),
+		2024: {
+			{Year: 2024, Month: time.January, Revenue: 388},
+		},
+	}
+	asm := Assumptions{
+		GrossMargin:       0.173,
+		TaxRate:           0.2,
+		SharesOutstanding: 80,
+		PerMultiple:       23,
+		CurrentPrice:      56,
+	}
+	projection, err := BuildYearProjection(2024, grouped, nil, asm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if projection.AvgYoY.LessThanOrEqual(decimal.Zero) {
+		t.Fatalf("expected positive avg YoY, got %v", projection.AvgYoY)
+	}
+	annualEPS := projection.AnnualEPS.InexactFloat64()
+	avgYoY := projection.AvgYoY.InexactFloat64()
+	wantIntrinsic := annualEPS * (8.5 + 2*avgYoY*100) * 4.4 / 4.4
+	if math.Abs(projection.IntrinsicValue.InexactFloat64()-wantIntrinsic) > 1e-2 {
+		t.Fatalf("unexpected intrinsic value: got %v want %f", projection.IntrinsicValue, wantIntrinsic)
+	}
+	wantMargin := (wantIntrinsic - asm.CurrentPrice) / wantIntrinsic
+	if math.Abs(projection.MarginOfSafety.InexactFloat64()-wantMargin) > 1e-3 {
+		t.Fatalf("unexpected margin of safety: got %v want %f", projection.MarginOfSafety, wantMargin)
+	}
+}
+
+func TestGrahamIntrinsicValueNegativeGrowthClamped(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildMonthlyRevenue(2023, []float64{400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400}),
+		2024: {
+			{Year: 2024, Month: time.January, Revenue: 300},
+		},
+	}
+	asm := Assumptions{
+		GrossMargin:       0.173,
+		TaxRate:           0.2,
+		SharesOutstanding: 80,
+		PerMultiple:       23,
+		CurrentPrice:      56,
+		BondYield:         5.5,
+	}
+	projection, err := BuildYearProjection(2024, grouped, nil, asm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !projection.AvgYoY.LessThan(decimal.Zero) {
+		t.Fatalf("expected negative avg YoY, got %v", projection.AvgYoY)
+	}
+	annualEPS := projection.AnnualEPS.InexactFloat64()
+	wantIntrinsic := annualEPS * 8.5 * 4.4 / 5.5
+	if math.Abs(projection.IntrinsicValue.InexactFloat64()-wantIntrinsic) > 1e-2 {
+		t.Fatalf("expected negative growth to clamp at 0: got %v want %f", projection.IntrinsicValue, wantIntrinsic)
+	}
+}
+
+func TestGrahamIntrinsicValueGrowthRateOverride(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildMonthlyRevenue(2023, []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}),
+		2024: {
+			{Year: 2024, Month: time.January, Revenue: 388},
+		},
+	}
+	asm := Assumptions{
+		GrossMargin:       0.173,
+		TaxRate:           0.2,
+		SharesOutstanding: 80,
+		PerMultiple:       23,
+		CurrentPrice:      300,
+		GrowthRate:        0.05,
+	}
+	projection, err := BuildYearProjection(2024, grouped, nil, asm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	annualEPS := projection.AnnualEPS.InexactFloat64()
+	wantIntrinsic := annualEPS * (8.5 + 2*0.05*100) * 4.4 / 4.4
+	if math.Abs(projection.IntrinsicValue.InexactFloat64()-wantIntrinsic) > 1e-2 {
+		t.Fatalf("expected GrowthRate override to be used: got %v want %f", projection.IntrinsicValue, wantIntrinsic)
+	}
+	if !projection.MarginOfSafety.LessThan(decimal.Zero) {
+		t.Fatalf("expected negative margin of safety when CurrentPrice exceeds intrinsic value, got %v", projection.MarginOfSafety)
+	}
+}
+
+func TestRoundingPolicyDefaults(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildMonthlyRevenue(2023, []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}),
+		2024: {
+			{Year: 2024, Month: time.January, Revenue: 333.333},
+		},
+	}
+	asm := Assumptions{
+		GrossMargin:       0.173,
+		TaxRate:           0.2,
+		SharesOutstanding: 80,
+		PerMultiple:       23,
+		CurrentPrice:      56,
+	}
+	projection, err := BuildYearProjection(2024, grouped, nil, asm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jan := projection.Months[0]
+	if places := jan.Revenue.Exponent(); places < -2 {
+		t.Fatalf("expected revenue rounded to 2 decimal places, got exponent %d (%v)", places, jan.Revenue)
+	}
+}
+
 func buildMonthlyRevenue(year int, values []float64) []revenue.MonthlyRevenue {
 	out := make([]revenue.MonthlyRevenue, len(values))
 	for i, val := range values {
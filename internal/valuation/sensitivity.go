@@ -0,0 +1,168 @@
+package valuation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+	"github.com/yilab8/stock_auto_work/internal/valuation/predict"
+)
+
+// SensitivityAxis 列舉可供敏感度分析掃描的 Assumptions 欄位。
+type SensitivityAxis string
+
+const (
+	AxisGrossMargin        SensitivityAxis = "GrossMargin"
+	AxisOperatingExpense   SensitivityAxis = "OperatingExpense"
+	AxisNonOperatingIncome SensitivityAxis = "NonOperatingIncome"
+	AxisTaxRate            SensitivityAxis = "TaxRate"
+	AxisPerMultiple        SensitivityAxis = "PerMultiple"
+	AxisPrevQuarterEPS     SensitivityAxis = "PrevQuarterEPS"
+)
+
+// ValidSensitivityAxis 回報 axis 是否為 SensitivityMatrix 支援的掃描欄位。
+func ValidSensitivityAxis(axis SensitivityAxis) bool {
+	switch axis {
+	case AxisGrossMargin, AxisOperatingExpense, AxisNonOperatingIncome, AxisTaxRate, AxisPerMultiple, AxisPrevQuarterEPS:
+		return true
+	default:
+		return false
+	}
+}
+
+// SensitivityRange 定義單一掃描軸：以 Assumptions 目前的值為中心，在 ±Delta 範圍內
+// 均分為 Steps 個格點 (Steps 小於等於 1 時僅使用基準值)。
+type SensitivityRange struct {
+	Axis  SensitivityAxis
+	Delta float64
+	Steps int
+}
+
+// MaxSensitivitySteps 為單一掃描軸允許的最大格點數；BuildSensitivityMatrix 會對每個交叉
+// 組合重新呼叫 BuildYearProjection，未設上限時過大的 Steps 會造成可輕易觸發的 CPU/記憶體
+// 耗用。
+const MaxSensitivitySteps = 50
+
+// SensitivityCell 為單一格點重新計算 BuildYearProjection 後的結果。
+type SensitivityCell struct {
+	RowValue       float64
+	ColValue       float64
+	EstimatedPrice decimal.Decimal
+	Upside         decimal.Decimal
+	Err            string
+}
+
+// SensitivityMatrix 為兩個掃描軸交叉出的 EstimatedPrice / Upside 矩陣，Cells 依
+// [row][col] 排列，列對應 RowRange，欄對應 ColRange。
+type SensitivityMatrix struct {
+	RowAxis   SensitivityAxis
+	ColAxis   SensitivityAxis
+	RowValues []float64
+	ColValues []float64
+	Cells     [][]SensitivityCell
+}
+
+// BuildSensitivityMatrix 以 base 為基準，分別沿 rowRange.Axis 與 colRange.Axis 掃描，
+// 對每個交叉組合重新呼叫 BuildYearProjection 取得 EstimatedPrice 與 Upside，用以呈現
+// 目標價對關鍵假設的敏感程度。rowRange.Axis 與 colRange.Axis 不得相同。
+func BuildSensitivityMatrix(year int, grouped map[int][]revenue.MonthlyRevenue, manualYoY map[time.Month]float64, base Assumptions, rowRange, colRange SensitivityRange, rules ...predict.Rule) (SensitivityMatrix, error) {
+	if !ValidSensitivityAxis(rowRange.Axis) {
+		return SensitivityMatrix{}, fmt.Errorf("不支援的掃描軸: %s", rowRange.Axis)
+	}
+	if !ValidSensitivityAxis(colRange.Axis) {
+		return SensitivityMatrix{}, fmt.Errorf("不支援的掃描軸: %s", colRange.Axis)
+	}
+	if rowRange.Axis == colRange.Axis {
+		return SensitivityMatrix{}, fmt.Errorf("兩個掃描軸不可相同: %s", rowRange.Axis)
+	}
+	if rowRange.Steps > MaxSensitivitySteps {
+		return SensitivityMatrix{}, fmt.Errorf("row_steps 超出上限 %d: %d", MaxSensitivitySteps, rowRange.Steps)
+	}
+	if colRange.Steps > MaxSensitivitySteps {
+		return SensitivityMatrix{}, fmt.Errorf("col_steps 超出上限 %d: %d", MaxSensitivitySteps, colRange.Steps)
+	}
+
+	rowValues := sensitivitySteps(axisValue(base, rowRange.Axis), rowRange.Delta, rowRange.Steps)
+	colValues := sensitivitySteps(axisValue(base, colRange.Axis), colRange.Delta, colRange.Steps)
+
+	cells := make([][]SensitivityCell, len(rowValues))
+	for i, rowValue := range rowValues {
+		rowAsm := withAxisValue(base, rowRange.Axis, rowValue)
+		cells[i] = make([]SensitivityCell, len(colValues))
+		for j, colValue := range colValues {
+			asm := withAxisValue(rowAsm, colRange.Axis, colValue)
+			projection, err := BuildYearProjection(year, grouped, manualYoY, asm, rules...)
+			cell := SensitivityCell{RowValue: rowValue, ColValue: colValue}
+			if err != nil {
+				cell.Err = err.Error()
+			} else {
+				cell.EstimatedPrice = projection.EstimatedPrice
+				cell.Upside = projection.Upside
+			}
+			cells[i][j] = cell
+		}
+	}
+
+	return SensitivityMatrix{
+		RowAxis:   rowRange.Axis,
+		ColAxis:   colRange.Axis,
+		RowValues: rowValues,
+		ColValues: colValues,
+		Cells:     cells,
+	}, nil
+}
+
+// sensitivitySteps 以 center 為中心在 ±delta 範圍內均分出 steps 個格點；
+// steps 小於等於 1 時僅回傳基準值本身。
+func sensitivitySteps(center, delta float64, steps int) []float64 {
+	if steps <= 1 {
+		return []float64{center}
+	}
+	values := make([]float64, steps)
+	start := center - delta
+	span := 2 * delta
+	for i := 0; i < steps; i++ {
+		values[i] = start + span*float64(i)/float64(steps-1)
+	}
+	return values
+}
+
+// axisValue 取得 asm 中 axis 對應欄位的目前值。
+func axisValue(asm Assumptions, axis SensitivityAxis) float64 {
+	switch axis {
+	case AxisGrossMargin:
+		return asm.GrossMargin
+	case AxisOperatingExpense:
+		return asm.OperatingExpense
+	case AxisNonOperatingIncome:
+		return asm.NonOperatingIncome
+	case AxisTaxRate:
+		return asm.TaxRate
+	case AxisPerMultiple:
+		return asm.PerMultiple
+	case AxisPrevQuarterEPS:
+		return asm.PrevQuarterEPS
+	default:
+		return 0
+	}
+}
+
+// withAxisValue 回傳 asm 的副本，並將 axis 對應欄位改為 v。
+func withAxisValue(asm Assumptions, axis SensitivityAxis, v float64) Assumptions {
+	switch axis {
+	case AxisGrossMargin:
+		asm.GrossMargin = v
+	case AxisOperatingExpense:
+		asm.OperatingExpense = v
+	case AxisNonOperatingIncome:
+		asm.NonOperatingIncome = v
+	case AxisTaxRate:
+		asm.TaxRate = v
+	case AxisPerMultiple:
+		asm.PerMultiple = v
+	case AxisPrevQuarterEPS:
+		asm.PrevQuarterEPS = v
+	}
+	return asm
+}
@@ -0,0 +1,126 @@
+package valuation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yilab8/stock_auto_work/internal/revenue"
+)
+
+func baseSensitivityAssumptions() Assumptions {
+	return Assumptions{
+		GrossMargin:        0.173,
+		OperatingExpense:   38,
+		NonOperatingIncome: 38,
+		TaxRate:            0.2,
+		SharesOutstanding:  80,
+		PerMultiple:        23,
+		CurrentPrice:       56,
+	}
+}
+
+func TestBuildSensitivityMatrixGrid(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildMonthlyRevenue(2023, []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}),
+		2024: buildMonthlyRevenue(2024, []float64{310, 290, 330, 340, 350, 360, 370, 380, 390, 400, 410, 420}),
+	}
+	base := baseSensitivityAssumptions()
+
+	matrix, err := BuildSensitivityMatrix(2024, grouped, nil, base,
+		SensitivityRange{Axis: AxisGrossMargin, Delta: 0.02, Steps: 3},
+		SensitivityRange{Axis: AxisPerMultiple, Delta: 2, Steps: 3},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matrix.RowValues) != 3 || len(matrix.ColValues) != 3 {
+		t.Fatalf("expected a 3x3 grid, got %dx%d", len(matrix.RowValues), len(matrix.ColValues))
+	}
+	if math.Abs(matrix.RowValues[1]-base.GrossMargin) > 1e-9 {
+		t.Fatalf("expected middle row value to equal the base GrossMargin, got %f", matrix.RowValues[1])
+	}
+	if math.Abs(matrix.ColValues[1]-base.PerMultiple) > 1e-9 {
+		t.Fatalf("expected middle column value to equal the base PerMultiple, got %f", matrix.ColValues[1])
+	}
+
+	center := matrix.Cells[1][1]
+	if center.Err != "" {
+		t.Fatalf("unexpected error in center cell: %s", center.Err)
+	}
+	projection, err := BuildYearProjection(2024, grouped, nil, base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !center.EstimatedPrice.Equal(projection.EstimatedPrice) {
+		t.Fatalf("expected center cell to match the base projection: %v vs %v", center.EstimatedPrice, projection.EstimatedPrice)
+	}
+
+	higherPER := matrix.Cells[1][2]
+	if !higherPER.EstimatedPrice.GreaterThan(center.EstimatedPrice) {
+		t.Fatalf("expected a higher PerMultiple to raise EstimatedPrice: %v vs %v", higherPER.EstimatedPrice, center.EstimatedPrice)
+	}
+}
+
+func TestBuildSensitivityMatrixSingleStep(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2023: buildMonthlyRevenue(2023, []float64{300, 280, 320, 330, 340, 350, 360, 370, 380, 390, 400, 410}),
+		2024: buildMonthlyRevenue(2024, []float64{310, 290, 330, 340, 350, 360, 370, 380, 390, 400, 410, 420}),
+	}
+	base := baseSensitivityAssumptions()
+
+	matrix, err := BuildSensitivityMatrix(2024, grouped, nil, base,
+		SensitivityRange{Axis: AxisTaxRate, Delta: 0.05, Steps: 1},
+		SensitivityRange{Axis: AxisOperatingExpense, Delta: 5, Steps: 1},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matrix.RowValues) != 1 || len(matrix.ColValues) != 1 {
+		t.Fatalf("expected a 1x1 grid when Steps<=1, got %dx%d", len(matrix.RowValues), len(matrix.ColValues))
+	}
+}
+
+func TestBuildSensitivityMatrixRejectsSameAxis(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2024: buildMonthlyRevenue(2024, []float64{310, 290, 330, 340, 350, 360, 370, 380, 390, 400, 410, 420}),
+	}
+	base := baseSensitivityAssumptions()
+
+	_, err := BuildSensitivityMatrix(2024, grouped, nil, base,
+		SensitivityRange{Axis: AxisPerMultiple, Delta: 2, Steps: 3},
+		SensitivityRange{Axis: AxisPerMultiple, Delta: 2, Steps: 3},
+	)
+	if err == nil {
+		t.Fatalf("expected an error when both axes are the same")
+	}
+}
+
+func TestBuildSensitivityMatrixRejectsExcessiveSteps(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2024: buildMonthlyRevenue(2024, []float64{310, 290, 330, 340, 350, 360, 370, 380, 390, 400, 410, 420}),
+	}
+	base := baseSensitivityAssumptions()
+
+	_, err := BuildSensitivityMatrix(2024, grouped, nil, base,
+		SensitivityRange{Axis: AxisGrossMargin, Delta: 0.02, Steps: MaxSensitivitySteps + 1},
+		SensitivityRange{Axis: AxisPerMultiple, Delta: 2, Steps: 3},
+	)
+	if err == nil {
+		t.Fatalf("expected an error when row Steps exceeds MaxSensitivitySteps")
+	}
+}
+
+func TestBuildSensitivityMatrixRejectsUnknownAxis(t *testing.T) {
+	grouped := map[int][]revenue.MonthlyRevenue{
+		2024: buildMonthlyRevenue(2024, []float64{310, 290, 330, 340, 350, 360, 370, 380, 390, 400, 410, 420}),
+	}
+	base := baseSensitivityAssumptions()
+
+	_, err := BuildSensitivityMatrix(2024, grouped, nil, base,
+		SensitivityRange{Axis: "NotAnAxis", Delta: 2, Steps: 3},
+		SensitivityRange{Axis: AxisPerMultiple, Delta: 2, Steps: 3},
+	)
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported axis")
+	}
+}